@@ -0,0 +1,97 @@
+package camera
+
+import (
+	"goray/color"
+	"goray/world"
+	"math"
+	"math/rand"
+)
+
+// adaptivePixel subdivides a pixel into quadrants, sampling their corners and
+// recursing only where the corners disagree by more than the threshold.
+// Corners are addressed on a grid of scale x scale cells so that samples
+// shared by neighbouring quadrants are traced once.
+type adaptivePixel struct {
+	camera  *Camera
+	world   *world.World
+	x, y    int
+	scale   int
+	rng     *rand.Rand
+	samples map[[2]int]*color.Color
+}
+
+func (c *Camera) renderAdaptivePixel(w *world.World, x, y int, rng *rand.Rand) (*color.Color, int) {
+	depth := c.AdaptiveDepth
+	if depth < 0 {
+		depth = 0
+	}
+
+	p := &adaptivePixel{camera: c, world: w, x: x, y: y, scale: 1 << uint(depth), rng: rng, samples: map[[2]int]*color.Color{}}
+	col := p.sample(0, 0, p.scale)
+
+	return col, len(p.samples)
+}
+
+// maxAdaptiveSamples is the number of samples a pixel takes when it is
+// subdivided all the way down
+func (c *Camera) maxAdaptiveSamples() int {
+	depth := c.AdaptiveDepth
+	if depth < 0 {
+		depth = 0
+	}
+	side := 1<<uint(depth) + 1
+
+	return side * side
+}
+
+func (p *adaptivePixel) at(i, j int) *color.Color {
+	key := [2]int{i, j}
+	if col, ok := p.samples[key]; ok {
+		return col
+	}
+
+	dx := float64(i) / float64(p.scale)
+	dy := float64(j) / float64(p.scale)
+	col := p.camera.traceSample(p.world, p.x, p.y, dx, dy, p.rng)
+	p.samples[key] = col
+
+	return col
+}
+
+func (p *adaptivePixel) sample(i, j, size int) *color.Color {
+	corners := []*color.Color{p.at(i, j), p.at(i+size, j), p.at(i, j+size), p.at(i+size, j+size)}
+	if size == 1 || !exceedsContrast(corners, p.camera.AdaptiveThreshold) {
+		return average(corners)
+	}
+
+	half := size / 2
+
+	return average([]*color.Color{
+		p.sample(i, j, half),
+		p.sample(i+half, j, half),
+		p.sample(i, j+half, half),
+		p.sample(i+half, j+half, half),
+	})
+}
+
+func exceedsContrast(colors []*color.Color, threshold float64) bool {
+	for i := range colors {
+		for j := i + 1; j < len(colors); j++ {
+			a, b := colors[i], colors[j]
+			if math.Abs(a.Red-b.Red) > threshold || math.Abs(a.Green-b.Green) > threshold || math.Abs(a.Blue-b.Blue) > threshold {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func average(colors []*color.Color) *color.Color {
+	sum := color.NewColor(0, 0, 0)
+	for _, c := range colors {
+		sum = sum.Add(c)
+	}
+
+	return sum.MultiplyScalar(1 / float64(len(colors)))
+}
@@ -0,0 +1,106 @@
+package camera
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/transformation"
+	"goray/tuple"
+	"goray/world"
+	"math"
+	"testing"
+)
+
+func newAdaptiveTestCamera() *Camera {
+	c := NewCamera(21, 21, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	c.AdaptiveThreshold = 0.05
+
+	return c
+}
+
+func TestAdaptiveSamplingTakesFourSamplesInFlatRegions(t *testing.T) {
+	c := newAdaptiveTestCamera()
+
+	im, counts := c.RenderWithSampleCounts(world.NewWorld())
+
+	level := 4.0 / 81
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			assert.True(t, color.NewColor(0, 0, 0).Equals(im.PixelAt(x, y)))
+			assert.True(t, color.NewColor(level, level, level).Equals(counts.PixelAt(x, y)))
+		}
+	}
+}
+
+func TestAdaptiveSamplingRefinesAlongEdges(t *testing.T) {
+	c := newAdaptiveTestCamera()
+
+	_, counts := c.RenderWithSampleCounts(world.NewDefaultWorld())
+
+	background := counts.PixelAt(0, 0).Red
+	edge := 0.0
+	for x := 0; x < c.HSize; x++ {
+		edge = math.Max(edge, counts.PixelAt(x, 10).Red)
+	}
+
+	assert.InDelta(t, 4.0/81, background, 0.00001)
+	assert.Greater(t, edge, background)
+	assert.LessOrEqual(t, edge, 1.0)
+}
+
+func TestAdaptiveSamplingRespectsDepthCap(t *testing.T) {
+	c := newAdaptiveTestCamera()
+	c.AdaptiveThreshold = 0.000001
+	c.AdaptiveDepth = 1
+
+	_, counts := c.RenderWithSampleCounts(world.NewDefaultWorld())
+
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			assert.LessOrEqual(t, counts.PixelAt(x, y).Red, 1.0)
+		}
+	}
+	assert.Equal(t, 9, c.maxAdaptiveSamples())
+}
+
+func TestAdaptiveSamplingWithoutRefinementAveragesPixelCorners(t *testing.T) {
+	w := world.NewDefaultWorld()
+	c := newAdaptiveTestCamera()
+	c.AdaptiveThreshold = 10
+
+	im := c.Render(w)
+
+	expected := average([]*color.Color{
+		w.ColorAt(c.RayForPixelOffset(7, 10, 0, 0), w.MaxDepth),
+		w.ColorAt(c.RayForPixelOffset(7, 10, 1, 0), w.MaxDepth),
+		w.ColorAt(c.RayForPixelOffset(7, 10, 0, 1), w.MaxDepth),
+		w.ColorAt(c.RayForPixelOffset(7, 10, 1, 1), w.MaxDepth),
+	})
+	assert.True(t, expected.Equals(im.PixelAt(7, 10)))
+}
+
+func TestUniformSamplingCountsAreFull(t *testing.T) {
+	c := newAdaptiveTestCamera()
+	c.AdaptiveThreshold = 0
+	c.Samples = 4
+
+	_, counts := c.RenderWithSampleCounts(world.NewDefaultWorld())
+
+	assert.True(t, color.NewColor(1, 1, 1).Equals(counts.PixelAt(3, 3)))
+}
+
+func TestExceedsContrast(t *testing.T) {
+	grey := color.NewColor(0.5, 0.5, 0.5)
+
+	assert.False(t, exceedsContrast([]*color.Color{grey, color.NewColor(0.55, 0.5, 0.45)}, 0.1))
+	assert.True(t, exceedsContrast([]*color.Color{grey, grey, color.NewColor(0.5, 0.7, 0.5)}, 0.1))
+}
+
+func TestRenderMatchesImageFromRenderWithSampleCounts(t *testing.T) {
+	c := newAdaptiveTestCamera()
+	w := world.NewDefaultWorld()
+
+	im, _ := c.RenderWithSampleCounts(w)
+
+	assert.Equal(t, im.ToPPM(), c.Render(w).ToPPM())
+}
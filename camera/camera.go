@@ -2,28 +2,79 @@ package camera
 
 import (
 	"goray/canvas"
+	"goray/color"
 	"goray/matrix"
 	"goray/ray"
 	"goray/tuple"
 	"goray/world"
-	"math"
+	"math/rand"
+	"runtime"
+	"sync"
 )
 
+const DefaultAdaptiveDepth = 3
+
 type Camera struct {
 	HSize       int
 	VSize       int
 	FieldOfView float64
-	Transform   *matrix.Matrix
+	// Transform is the view transformation. SetTransformation also caches
+	// its inverse; assigning the field directly still works, the inverse is
+	// then recomputed the next time a ray is cast
+	Transform *matrix.Matrix
+	Workers   int
+
+	// Samples is the number of rays averaged into each pixel, placed
+	// according to SamplePattern, stratified unless set otherwise; a single
+	// sample always goes through the pixel centre. Random patterns are seeded
+	// from Seed and the row number so renders are reproducible regardless of
+	// Workers
+	Samples       int
+	SamplePattern SamplePattern
+	Seed          int64
+
+	// AdaptiveThreshold switches Render to adaptive supersampling when
+	// positive: pixels are refined only where samples differ by more than
+	// the threshold in any channel, at most AdaptiveDepth times
+	AdaptiveThreshold float64
+	AdaptiveDepth     int
+
+	// Aperture is the radius of a thin lens around the eye; rays start on
+	// the lens and converge on the plane FocalDistance in front of it, so
+	// only objects near that plane are sharp. Blades of three or more give
+	// the lens a polygonal shape instead of a disc.
+	Aperture      float64
+	FocalDistance float64
+	Blades        int
+
+	// ShutterOpen and ShutterClose bound the times at which rays are cast;
+	// moving shapes blur across the interval
+	ShutterOpen  float64
+	ShutterClose float64
 
 	PixelSize  float64
 	HalfWidth  float64
 	HalfHeight float64
+
+	projection Projection
+	inverse    *matrix.Matrix
+	inverseOf  *matrix.Matrix
 }
 
 func NewCamera(hsize, vsize int, fov float64) *Camera {
-	c := &Camera{HSize: hsize, VSize: vsize, FieldOfView: fov, Transform: matrix.NewIdentityMatrix4x4()}
+	c := &Camera{HSize: hsize, VSize: vsize, FieldOfView: fov, Workers: runtime.NumCPU(), Samples: 1, SamplePattern: StratifiedSampling, AdaptiveDepth: DefaultAdaptiveDepth, FocalDistance: 1, projection: Perspective{}}
+	c.SetTransformation(matrix.NewIdentityMatrix4x4())
+	c.Resize(hsize, vsize)
+
+	return c
+}
 
-	halfView := math.Tan(c.FieldOfView / 2)
+// Resize changes the resolution, keeping the field of view and every other
+// setting
+func (c *Camera) Resize(hsize, vsize int) {
+	c.HSize, c.VSize = hsize, vsize
+
+	halfView := c.projection.halfView(c)
 	aspect := float64(c.HSize) / float64(c.VSize)
 
 	if aspect >= 1 {
@@ -35,36 +86,195 @@ func NewCamera(hsize, vsize int, fov float64) *Camera {
 	}
 
 	c.PixelSize = (c.HalfWidth * 2) / float64(c.HSize)
+}
 
-	return c
+// SetProjection switches how pixels map to rays; the camera starts out with
+// a Perspective projection
+func (c *Camera) SetProjection(p Projection) {
+	c.projection = p
+	c.Resize(c.HSize, c.VSize)
+}
+
+func (c *Camera) GetProjection() Projection {
+	return c.projection
+}
+
+func (c *Camera) SetTransformation(m *matrix.Matrix) {
+	c.Transform = m
+	c.inverse = m.Invert()
+	c.inverseOf = m
+}
+
+func (c *Camera) GetTransformation() *matrix.Matrix {
+	return c.Transform
+}
+
+func (c *Camera) GetInverse() *matrix.Matrix {
+	if c.inverseOf != c.Transform {
+		c.SetTransformation(c.Transform)
+	}
+
+	return c.inverse
 }
 
 func (c *Camera) RayForPixel(x, y int) *ray.Ray {
-	xOffset := (float64(x) + 0.5) * c.PixelSize
-	yOffset := (float64(y) + 0.5) * c.PixelSize
+	return c.RayForPixelOffset(x, y, 0.5, 0.5)
+}
+
+// RayForPixelOffset shoots a ray through the point (dx, dy) of the pixel,
+// where (0, 0) is its top left corner and (1, 1) its bottom right
+func (c *Camera) RayForPixelOffset(x, y int, dx, dy float64) *ray.Ray {
+	return c.RayThroughLens(x, y, dx, dy, 0.5, 0.5)
+}
+
+// RayThroughLens is RayForPixelOffset for a ray leaving the lens at (lu, lv),
+// which maps [0, 1) x [0, 1) onto the aperture with (0.5, 0.5) at its centre.
+// It returns nil when the projection leaves that point of the image empty
+func (c *Camera) RayThroughLens(x, y int, dx, dy, lu, lv float64) *ray.Ray {
+	xOffset := (float64(x) + dx) * c.PixelSize
+	yOffset := (float64(y) + dy) * c.PixelSize
 
 	worldX := c.HalfWidth - xOffset
 	worldY := c.HalfHeight - yOffset
 
-	transformInvert := c.Transform.Invert()
-	pixel := transformInvert.MultiplyTuple(tuple.NewPoint(worldX, worldY, -1))
-	origin := transformInvert.MultiplyTuple(tuple.NewPoint(0, 0, 0))
-	direction := pixel.Sub(origin).Normalize()
+	start, towards, ok := c.projection.cameraRay(c, worldX, worldY)
+	if !ok {
+		return nil
+	}
+
+	if c.Aperture <= 0 {
+		inverse := c.GetInverse()
+		pixel := inverse.MultiplyTuple(start.Add(towards))
+		origin := inverse.MultiplyTuple(start)
+		direction := pixel.Sub(origin).Normalize()
+
+		r := ray.NewRay(origin, direction)
+		r.Time = c.ShutterOpen
+
+		return r
+	}
+
+	lensX, lensY := c.lensPoint(lu, lv)
+	inverse := c.GetInverse()
+	focus := inverse.MultiplyTuple(start.Add(towards.Multiply(c.FocalDistance)))
+	origin := inverse.MultiplyTuple(start.Add(tuple.NewVector(lensX, lensY, 0)))
+	direction := focus.Sub(origin).Normalize()
 
-	return ray.NewRay(origin, direction)
+	r := ray.NewRay(origin, direction)
+	r.Time = c.ShutterOpen
+
+	return r
+}
+
+// traceSample colors the ray through (dx, dy) of the pixel, drawing a random
+// point on the lens when the camera has an aperture and a random time when
+// the shutter is open for a while; the row's generator also jitters soft
+// light samples, so renders do not depend on how rows are shared out
+func (c *Camera) traceSample(w *world.World, x, y int, dx, dy float64, rng *rand.Rand) *color.Color {
+	lu, lv := 0.5, 0.5
+	if c.Aperture > 0 {
+		lu, lv = rng.Float64(), rng.Float64()
+	}
+
+	r := c.RayThroughLens(x, y, dx, dy, lu, lv)
+	if r == nil {
+		return color.NewColor(0, 0, 0)
+	}
+	r.Jitter = rng
+	if c.ShutterClose > c.ShutterOpen {
+		r.Time += rng.Float64() * (c.ShutterClose - c.ShutterOpen)
+	}
+
+	return w.ColorAt(r, w.MaxDepth)
 }
 
 func (c *Camera) Render(w *world.World) *canvas.Canvas {
 	im := canvas.NewCanvas(c.HSize, c.VSize)
+	c.render(w, im, nil)
+
+	return im
+}
 
+// RenderWithSampleCounts also returns a debug canvas whose brightness shows
+// how many samples each pixel took, relative to the most it could have taken
+func (c *Camera) RenderWithSampleCounts(w *world.World) (*canvas.Canvas, *canvas.Canvas) {
+	im := canvas.NewCanvas(c.HSize, c.VSize)
+	counts := canvas.NewCanvas(c.HSize, c.VSize)
+	c.render(w, im, counts)
+
+	return im, counts
+}
+
+// render fills im, and counts unless it is nil, using Workers goroutines
+func (c *Camera) render(w *world.World, im, counts *canvas.Canvas) {
+	// refresh the cached inverse before workers share it
+	c.GetInverse()
+
+	workers := c.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	rows := make(chan int, c.VSize)
 	for y := 0; y < c.VSize; y++ {
-		for x := 0; x < c.HSize; x++ {
-			r := c.RayForPixel(x, y)
-			c := w.ColorAt(r)
+		rows <- y
+	}
+	close(rows)
 
-			im.WriteAt(x, y, c)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for y := range rows {
+				c.renderRow(w, im, counts, y)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// renderRow writes a single scanline; each row is owned by exactly one worker,
+// so no two goroutines ever write the same canvas cell.
+func (c *Camera) renderRow(w *world.World, im, counts *canvas.Canvas, y int) {
+	rng := rand.New(rand.NewSource(rowSeed(c.Seed, y)))
+
+	maxSamples := c.Samples
+	if c.AdaptiveThreshold > 0 {
+		maxSamples = c.maxAdaptiveSamples()
+	}
+	if maxSamples < 1 {
+		maxSamples = 1
+	}
+
+	for x := 0; x < c.HSize; x++ {
+		var col *color.Color
+		var n int
+		if c.AdaptiveThreshold > 0 {
+			col, n = c.renderAdaptivePixel(w, x, y, rng)
+		} else {
+			col, n = c.renderPixel(w, x, y, rng)
+		}
+
+		im.WriteAt(x, y, col)
+		if counts != nil {
+			level := float64(n) / float64(maxSamples)
+			counts.WriteAt(x, y, color.NewColor(level, level, level))
 		}
 	}
+}
 
-	return im
+func (c *Camera) renderPixel(w *world.World, x, y int, rng *rand.Rand) (*color.Color, int) {
+	if c.Samples <= 1 {
+		return c.traceSample(w, x, y, 0.5, 0.5, rng), 1
+	}
+
+	offsets := sampleOffsets(c.SamplePattern, c.Samples, rng)
+
+	colors := make([]*color.Color, len(offsets))
+	for i, o := range offsets {
+		colors[i] = c.traceSample(w, x, y, o[0], o[1], rng)
+	}
+
+	return average(colors), len(colors)
 }
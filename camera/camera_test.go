@@ -3,11 +3,14 @@ package camera
 import (
 	"github.com/stretchr/testify/assert"
 	"goray/color"
+	"goray/light"
 	"goray/matrix"
+	"goray/shape"
 	"goray/transformation"
 	"goray/tuple"
 	"goray/world"
 	"math"
+	"runtime"
 	"testing"
 )
 
@@ -21,7 +24,31 @@ func TestConstructingCamera(t *testing.T) {
 	assert.Equal(t, hsize, c.HSize)
 	assert.Equal(t, vsize, c.VSize)
 	assert.Equal(t, fieldOfView, c.FieldOfView)
-	assert.True(t, matrix.NewIdentityMatrix4x4().Equals(c.Transform))
+	assert.True(t, matrix.NewIdentityMatrix4x4().Equals(c.GetTransformation()))
+	assert.Equal(t, 1, c.Samples)
+	assert.Equal(t, StratifiedSampling, c.SamplePattern)
+}
+
+func TestAssigningTransformationCachesInverse(t *testing.T) {
+	c := NewCamera(160, 120, math.Pi/2)
+	m := transformation.NewRotationY(math.Pi / 4).MultiplyMatrix(transformation.NewTranslation(0, -2, 5))
+
+	c.SetTransformation(m)
+
+	assert.True(t, m.Equals(c.GetTransformation()))
+	assert.True(t, m.Invert().Equals(c.GetInverse()))
+}
+
+func TestAssigningTransformFieldDirectly(t *testing.T) {
+	c := NewCamera(201, 101, math.Pi/2)
+	m := transformation.NewRotationY(math.Pi / 4).MultiplyMatrix(transformation.NewTranslation(0, -2, 5))
+
+	c.Transform = m
+	r := c.RayForPixel(100, 50)
+
+	assert.True(t, m.Invert().Equals(c.GetInverse()))
+	assert.True(t, tuple.NewPoint(0, 2, -5).Equals(r.Origin))
+	assert.True(t, tuple.NewVector(math.Sqrt(2)/2, 0, -math.Sqrt(2)/2).Equals(r.Direction))
 }
 
 func TestPixelSizeForHorizontalCanvas(t *testing.T) {
@@ -56,7 +83,7 @@ func TestConstructingRayThroughCornerOfTheCanvas(t *testing.T) {
 
 func TestConstructingRayWithTransformedCamera(t *testing.T) {
 	c := NewCamera(201, 101, math.Pi/2)
-	c.Transform = transformation.NewRotationY(math.Pi / 4).MultiplyMatrix(transformation.NewTranslation(0, -2, 5))
+	c.SetTransformation(transformation.NewRotationY(math.Pi / 4).MultiplyMatrix(transformation.NewTranslation(0, -2, 5)))
 
 	r := c.RayForPixel(100, 50)
 
@@ -70,9 +97,128 @@ func TestRenderingWorldWithCamera(t *testing.T) {
 	from := tuple.NewPoint(0, 0, -5)
 	to := tuple.NewPoint(0, 0, 0)
 	up := tuple.NewVector(0, 1, 0)
-	c.Transform = transformation.ViewTransform(from, to, up)
+	c.SetTransformation(transformation.ViewTransform(from, to, up))
 
 	im := c.Render(w)
 
 	assert.True(t, color.NewColor(0.38066, 0.47583, 0.2855).Equals(im.PixelAt(5, 5)))
 }
+
+func TestDefaultWorkerCount(t *testing.T) {
+	c := NewCamera(160, 120, math.Pi/2)
+
+	assert.Equal(t, runtime.NumCPU(), c.Workers)
+}
+
+func TestParallelRenderMatchesSerialRender(t *testing.T) {
+	w := world.NewDefaultWorld()
+	c := NewCamera(40, 30, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 1, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	c.Workers = 1
+	serial := c.Render(w)
+
+	c.Workers = 8
+	parallel := c.Render(w)
+
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			assert.Equal(t, serial.PixelAt(x, y), parallel.PixelAt(x, y))
+		}
+	}
+}
+
+func TestSoftShadowsDoNotDependOnWorkerCount(t *testing.T) {
+	w := world.NewDefaultWorld()
+	l := light.NewJitteredAreaLight(tuple.NewPoint(-11, 9, -11), tuple.NewVector(2, 0, 0), 4, tuple.NewVector(0, 2, 0), 4, color.NewColor(1, 1, 1))
+	w.Lights = []*light.Light{l}
+	floor := shape.NewPlane()
+	floor.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	w.Objects = append(w.Objects, floor)
+	c := NewCamera(30, 20, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 1, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	c.Seed = 3
+
+	c.Workers = 1
+	serial := c.Render(w)
+	c.Workers = 8
+	parallel := c.Render(w)
+
+	assert.Equal(t, serial.ToPPM(), parallel.ToPPM())
+}
+
+func TestRenderingWithBoundingVolumeHierarchyMatchesLinearRender(t *testing.T) {
+	w := world.NewDefaultWorld()
+	floor := shape.NewPlane()
+	floor.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	w.Objects = append(w.Objects, floor)
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			s := shape.NewSphere()
+			s.SetTransformation(transformation.NewTranslation(float64(i)-2, float64(j)-2, 3).MultiplyMatrix(transformation.NewScaling(0.3, 0.3, 0.3)))
+			w.Objects = append(w.Objects, s)
+		}
+	}
+	c := NewCamera(30, 20, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 1, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	linear := c.Render(w)
+	w.Divide(2)
+	accelerated := c.Render(w)
+
+	assert.Equal(t, linear.ToPPM(), accelerated.ToPPM())
+}
+
+func TestRayForPixelOffsetThroughPixelCorner(t *testing.T) {
+	c := NewCamera(201, 101, math.Pi/2)
+
+	centre := c.RayForPixel(100, 50)
+	offset := c.RayForPixelOffset(100, 50, 0.5, 0.5)
+	corner := c.RayForPixelOffset(0, 0, 0, 0)
+
+	assert.True(t, centre.Direction.Equals(offset.Direction))
+	expected := tuple.NewPoint(c.HalfWidth, c.HalfHeight, -1)
+	expected = expected.Sub(tuple.NewPoint(0, 0, 0)).Normalize()
+	assert.True(t, expected.Equals(corner.Direction))
+}
+
+func TestSupersamplingAveragesSamplesAcrossEdges(t *testing.T) {
+	w := world.NewDefaultWorld()
+	c := NewCamera(11, 11, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	aliased := c.Render(w)
+	c.Samples = 16
+	smooth := c.Render(w)
+
+	// the centre of the sphere is flat enough that the average barely moves,
+	// while pixels on its silhouette blend with the black background
+	assert.InDelta(t, aliased.PixelAt(5, 5).Green, smooth.PixelAt(5, 5).Green, 0.05)
+	differs := false
+	for x := 0; x < c.HSize; x++ {
+		if !aliased.PixelAt(x, 5).Equals(smooth.PixelAt(x, 5)) {
+			differs = true
+		}
+	}
+	assert.True(t, differs)
+}
+
+func TestRandomSamplingIsReproducibleAcrossWorkerCounts(t *testing.T) {
+	w := world.NewDefaultWorld()
+	c := NewCamera(20, 15, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 1, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	c.Samples = 4
+	c.SamplePattern = StratifiedSampling
+	c.Seed = 42
+
+	c.Workers = 1
+	serial := c.Render(w)
+	c.Workers = 6
+	parallel := c.Render(w)
+
+	assert.Equal(t, serial.ToPPM(), parallel.ToPPM())
+
+	c.Seed = 43
+	reseeded := c.Render(w)
+	assert.NotEqual(t, serial.ToPPM(), reseeded.ToPPM())
+}
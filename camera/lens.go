@@ -0,0 +1,52 @@
+package camera
+
+import (
+	"math"
+)
+
+// lensPoint maps (u, v) in [0, 1) onto the aperture in camera space: a disc
+// of radius Aperture, or a regular polygon with that circumradius when the
+// camera has at least three Blades
+func (c *Camera) lensPoint(u, v float64) (float64, float64) {
+	if c.Blades >= 3 {
+		return c.polygonPoint(u, v)
+	}
+
+	return c.discPoint(u, v)
+}
+
+// discPoint uses Shirley's concentric mapping, which keeps samples that are
+// well spread in the unit square well spread on the disc
+func (c *Camera) discPoint(u, v float64) (float64, float64) {
+	a, b := 2*u-1, 2*v-1
+	if a == 0 && b == 0 {
+		return 0, 0
+	}
+
+	var r, theta float64
+	if math.Abs(a) > math.Abs(b) {
+		r, theta = a, math.Pi/4*(b/a)
+	} else {
+		r, theta = b, math.Pi/2-math.Pi/4*(a/b)
+	}
+	r *= c.Aperture
+
+	return r * math.Cos(theta), r * math.Sin(theta)
+}
+
+// polygonPoint picks one of the triangles fanning out from the centre to each
+// edge, then a uniform point within it
+func (c *Camera) polygonPoint(u, v float64) (float64, float64) {
+	n := float64(c.Blades)
+	sector := math.Floor(u * n)
+	u = u*n - sector
+
+	a0 := math.Pi/2 + 2*math.Pi*sector/n
+	a1 := a0 + 2*math.Pi/n
+
+	s := math.Sqrt(u)
+	x := s * ((1-v)*math.Cos(a0) + v*math.Cos(a1))
+	y := s * ((1-v)*math.Sin(a0) + v*math.Sin(a1))
+
+	return x * c.Aperture, y * c.Aperture
+}
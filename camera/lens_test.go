@@ -0,0 +1,125 @@
+package camera
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/transformation"
+	"goray/tuple"
+	"goray/utils"
+	"goray/world"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPinholeIgnoresLensSample(t *testing.T) {
+	c := NewCamera(201, 101, math.Pi/2)
+
+	assert.Equal(t, c.RayForPixel(10, 20), c.RayThroughLens(10, 20, 0.5, 0.5, 0.1, 0.9))
+}
+
+func TestLensCentreRayMatchesPinholeRay(t *testing.T) {
+	c := NewCamera(201, 101, math.Pi/2)
+	c.SetTransformation(transformation.NewRotationY(math.Pi / 4).MultiplyMatrix(transformation.NewTranslation(0, -2, 5)))
+	pinhole := c.RayForPixel(40, 30)
+
+	c.Aperture = 0.5
+	c.FocalDistance = 7
+	lens := c.RayForPixel(40, 30)
+
+	assert.True(t, pinhole.Origin.Equals(lens.Origin))
+	assert.True(t, pinhole.Direction.Equals(lens.Direction))
+}
+
+func TestLensRaysConvergeOnFocalPlane(t *testing.T) {
+	c := NewCamera(201, 101, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	c.Aperture = 0.3
+	c.FocalDistance = 5
+
+	// the focal plane is z = 0 in world space; every ray through the lens for
+	// the same pixel must cross it at the same point
+	var focus *tuple.Tuple
+	for _, s := range [][2]float64{{0.5, 0.5}, {0, 0}, {0.9, 0.2}, {0.3, 0.99}} {
+		r := c.RayThroughLens(150, 20, 0.5, 0.5, s[0], s[1])
+		p := r.Position(-r.Origin.Z / r.Direction.Z)
+
+		if focus == nil {
+			focus = p
+		}
+		assert.True(t, focus.Equals(p), "lens sample %v", s)
+	}
+}
+
+func TestDiscSamplesStayWithinAperture(t *testing.T) {
+	c := NewCamera(10, 10, math.Pi/2)
+	c.Aperture = 0.25
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		x, y := c.lensPoint(rng.Float64(), rng.Float64())
+
+		assert.LessOrEqual(t, math.Hypot(x, y), 0.25+utils.EPSILON)
+	}
+
+	x, y := c.lensPoint(1, 0.5)
+	assert.InDelta(t, 0.25, x, utils.EPSILON)
+	assert.InDelta(t, 0, y, utils.EPSILON)
+}
+
+func TestPolygonSamplesStayWithinBlades(t *testing.T) {
+	c := NewCamera(10, 10, math.Pi/2)
+	c.Aperture = 1
+	c.Blades = 6
+	rng := rand.New(rand.NewSource(1))
+
+	// a regular hexagon with circumradius 1 has an inradius of cos(pi/6), so
+	// samples never reach the unit circle but some land beyond the incircle
+	beyondIncircle := false
+	for i := 0; i < 1000; i++ {
+		x, y := c.lensPoint(rng.Float64(), rng.Float64())
+		r := math.Hypot(x, y)
+
+		assert.LessOrEqual(t, r, 1+utils.EPSILON)
+		if r > math.Cos(math.Pi/6) {
+			beyondIncircle = true
+		}
+	}
+	assert.True(t, beyondIncircle)
+
+	x, y := c.lensPoint(0, 0)
+	assert.InDelta(t, 0, x, utils.EPSILON)
+	assert.InDelta(t, 0, y, utils.EPSILON)
+}
+
+func TestResizeKeepsSettings(t *testing.T) {
+	c := NewCamera(200, 125, math.Pi/2)
+	c.Aperture = 0.1
+	c.Samples = 4
+
+	c.Resize(125, 200)
+
+	assert.Equal(t, 125, c.HSize)
+	assert.Equal(t, 200, c.VSize)
+	assert.InDelta(t, 0.01, c.PixelSize, utils.EPSILON)
+	assert.Equal(t, 0.1, c.Aperture)
+	assert.Equal(t, 4, c.Samples)
+}
+
+func TestDepthOfFieldBlursOutOfFocusObjects(t *testing.T) {
+	w := world.NewDefaultWorld()
+	c := NewCamera(21, 21, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	c.Samples = 16
+	c.Seed = 5
+	sharp := c.Render(w)
+
+	c.Aperture = 0.5
+	c.FocalDistance = 20
+	c.Workers = 1
+	blurred := c.Render(w)
+	c.Workers = 4
+	again := c.Render(w)
+
+	assert.NotEqual(t, sharp.ToPPM(), blurred.ToPPM())
+	assert.Equal(t, blurred.ToPPM(), again.ToPPM())
+}
@@ -0,0 +1,44 @@
+package camera
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/ray"
+	"goray/shape"
+	"goray/transformation"
+	"goray/tuple"
+	"goray/world"
+	"math"
+	"testing"
+)
+
+func TestRaysStartAtShutterOpen(t *testing.T) {
+	c := NewCamera(11, 11, math.Pi/2)
+	c.ShutterOpen = 0.25
+
+	assert.Equal(t, 0.25, c.RayForPixel(3, 4).Time)
+}
+
+func TestOpenShutterBlursMovingObjects(t *testing.T) {
+	w := world.NewDefaultWorld()
+	mover := shape.NewSphere()
+	mover.SetMotion(transformation.NewTranslation(-2, 0, -1), transformation.NewTranslation(2, 0, -1))
+	w.Objects = []ray.Object{mover}
+	c := NewCamera(21, 21, math.Pi/2)
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	c.Samples = 16
+	c.Seed = 3
+
+	frozen := c.Render(w)
+
+	c.ShutterClose = 1
+	blurred := c.Render(w)
+
+	// at shutter open the sphere sits left of centre; with the shutter open
+	// it sweeps through the centre and leaves a partial trail there
+	assert.True(t, frozen.PixelAt(10, 10).Equals(frozen.PixelAt(20, 0)))
+	assert.Greater(t, blurred.PixelAt(10, 10).Red, 0.0)
+	assert.Less(t, blurred.PixelAt(10, 10).Red, 1.0)
+
+	c.Workers = 1
+	assert.Equal(t, blurred.ToPPM(), c.Render(w).ToPPM())
+}
@@ -0,0 +1,152 @@
+package camera
+
+import (
+	"fmt"
+	"goray/tuple"
+	"math"
+)
+
+// Projection decides which ray leaves the camera for each point of the image.
+// Image points run from (HalfWidth, HalfHeight) at the top left corner to
+// (-HalfWidth, -HalfHeight) at the bottom right; for a perspective camera they
+// lie on the plane z = -1 of camera space
+type Projection interface {
+	// halfView is half the extent of the image plane along its longer side
+	halfView(c *Camera) float64
+	// cameraRay returns the origin and direction in camera space of the ray
+	// through (x, y), or false when the point lies outside the projection. The
+	// direction is not normalized; the camera focuses where origin + direction
+	// lands when scaled by FocalDistance
+	cameraRay(c *Camera, x, y float64) (*tuple.Tuple, *tuple.Tuple, bool)
+}
+
+// Perspective is a pinhole camera whose horizontal or vertical field of view,
+// whichever is wider, is the camera's FieldOfView
+type Perspective struct{}
+
+func (Perspective) halfView(c *Camera) float64 {
+	return math.Tan(c.FieldOfView / 2)
+}
+
+func (Perspective) cameraRay(c *Camera, x, y float64) (*tuple.Tuple, *tuple.Tuple, bool) {
+	return tuple.NewPoint(0, 0, 0), tuple.NewVector(x, y, -1), true
+}
+
+// Orthographic shoots parallel rays from a rectangle Width units across, so
+// objects keep their size however far they are; FieldOfView is ignored
+type Orthographic struct {
+	Width float64
+}
+
+func NewOrthographic(width float64) (Orthographic, error) {
+	if width <= 0 {
+		return Orthographic{}, fmt.Errorf("orthographic view width must be positive, got %v", width)
+	}
+
+	return Orthographic{Width: width}, nil
+}
+
+func (o Orthographic) halfView(c *Camera) float64 {
+	return o.Width / 2
+}
+
+func (o Orthographic) cameraRay(c *Camera, x, y float64) (*tuple.Tuple, *tuple.Tuple, bool) {
+	return tuple.NewPoint(x, y, 0), tuple.NewVector(0, 0, -1), true
+}
+
+// Fisheye is an equidistant fisheye: the angle between a ray and the view
+// direction grows linearly with its distance from the image centre, reaching
+// half the FieldOfView at the edge of the longer side. Fields of view up to
+// 2*pi are allowed; points beyond that angle stay black
+type Fisheye struct{}
+
+func (Fisheye) halfView(c *Camera) float64 {
+	return 1
+}
+
+func (Fisheye) cameraRay(c *Camera, x, y float64) (*tuple.Tuple, *tuple.Tuple, bool) {
+	r := math.Hypot(x, y)
+	theta := r * c.FieldOfView / 2
+	if theta > math.Pi {
+		return nil, nil, false
+	}
+
+	sinTheta := math.Sin(theta)
+	dx, dy := 0.0, 0.0
+	if r > 0 {
+		dx, dy = sinTheta*x/r, sinTheta*y/r
+	}
+
+	return tuple.NewPoint(0, 0, 0), tuple.NewVector(dx, dy, -math.Cos(theta)), true
+}
+
+// Equirectangular maps longitude across the image and latitude down it,
+// covering every direction around the camera; a 2:1 image keeps the
+// panorama undistorted. FieldOfView is ignored
+type Equirectangular struct{}
+
+func (Equirectangular) halfView(c *Camera) float64 {
+	return 1
+}
+
+func (Equirectangular) cameraRay(c *Camera, x, y float64) (*tuple.Tuple, *tuple.Tuple, bool) {
+	// image x grows to the left in camera space, so longitude runs from -pi at
+	// the left edge to pi at the right
+	longitude := -x / c.HalfWidth * math.Pi
+	latitude := y / c.HalfHeight * math.Pi / 2
+
+	return tuple.NewPoint(0, 0, 0), panoramaDirection(longitude, latitude), true
+}
+
+func panoramaDirection(longitude, latitude float64) *tuple.Tuple {
+	return tuple.NewVector(
+		-math.Cos(latitude)*math.Sin(longitude),
+		math.Sin(latitude),
+		-math.Cos(latitude)*math.Cos(longitude),
+	)
+}
+
+// CubePanorama lays the six 90 degree views around the camera out as a
+// horizontal cross, four faces wide and three high:
+//
+//	     up
+//	left front right back
+//	     down
+//
+// The unused cells stay black and FieldOfView is ignored
+type CubePanorama struct{}
+
+func (CubePanorama) halfView(c *Camera) float64 {
+	return 1
+}
+
+func (CubePanorama) cameraRay(c *Camera, x, y float64) (*tuple.Tuple, *tuple.Tuple, bool) {
+	u := math.Min(math.Max((c.HalfWidth-x)/(2*c.HalfWidth)*4, 0), 4-1e-9)
+	v := math.Min(math.Max((c.HalfHeight-y)/(2*c.HalfHeight)*3, 0), 3-1e-9)
+	col, row := math.Floor(u), math.Floor(v)
+
+	// (a, b) runs from (-1, -1) at the bottom left of a face to (1, 1) at its
+	// top right
+	a := 2*(u-col) - 1
+	b := 1 - 2*(v-row)
+
+	var direction *tuple.Tuple
+	switch {
+	case row == 0 && col == 1:
+		direction = tuple.NewVector(-a, 1, b)
+	case row == 2 && col == 1:
+		direction = tuple.NewVector(-a, -1, -b)
+	case row == 1 && col == 0:
+		direction = tuple.NewVector(1, b, -a)
+	case row == 1 && col == 1:
+		direction = tuple.NewVector(-a, b, -1)
+	case row == 1 && col == 2:
+		direction = tuple.NewVector(-1, b, a)
+	case row == 1 && col == 3:
+		direction = tuple.NewVector(a, b, 1)
+	default:
+		return nil, nil, false
+	}
+
+	return tuple.NewPoint(0, 0, 0), direction, true
+}
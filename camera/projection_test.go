@@ -0,0 +1,147 @@
+package camera
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/transformation"
+	"goray/tuple"
+	"goray/utils"
+	"goray/world"
+	"math"
+	"testing"
+)
+
+func TestCameraDefaultsToPerspective(t *testing.T) {
+	c := NewCamera(160, 120, math.Pi/2)
+
+	assert.Equal(t, Perspective{}, c.GetProjection())
+}
+
+func TestOrthographicRaysAreParallel(t *testing.T) {
+	c := NewCamera(201, 101, math.Pi/2)
+	o, err := NewOrthographic(4)
+	require.NoError(t, err)
+	c.SetProjection(o)
+	c.SetTransformation(transformation.NewRotationY(math.Pi / 4).MultiplyMatrix(transformation.NewTranslation(0, -2, 5)))
+
+	centre := c.RayForPixel(100, 50)
+	corner := c.RayForPixel(0, 0)
+
+	assert.InDelta(t, 4.0/201, c.PixelSize, utils.EPSILON)
+	assert.True(t, tuple.NewPoint(0, 2, -5).Equals(centre.Origin))
+	assert.True(t, tuple.NewVector(math.Sqrt(2)/2, 0, -math.Sqrt(2)/2).Equals(centre.Direction))
+	assert.True(t, centre.Direction.Equals(corner.Direction))
+	// the top left corner is half the view width to the camera's left and half
+	// its height above
+	offset := corner.Origin.Sub(centre.Origin)
+	assert.InDelta(t, math.Sqrt(2*2+1*1), offset.Magnitude(), 0.02)
+}
+
+func TestOrthographicWidthMustBePositive(t *testing.T) {
+	for _, width := range []float64{0, -2} {
+		_, err := NewOrthographic(width)
+
+		assert.Error(t, err, "width %v", width)
+	}
+}
+
+func TestFisheyeAngleGrowsWithDistanceFromCentre(t *testing.T) {
+	c := NewCamera(201, 201, math.Pi)
+	c.SetProjection(Fisheye{})
+
+	centre := c.RayForPixel(100, 100)
+	edge := c.RayThroughLens(0, 100, 0, 0.5, 0.5, 0.5)
+	halfway := c.RayThroughLens(50, 100, 0.25, 0.5, 0.5, 0.5)
+
+	assert.True(t, tuple.NewVector(0, 0, -1).Equals(centre.Direction))
+	// the left edge sits at half the 180 degree field of view, square to the
+	// view direction on the camera's left
+	assert.True(t, tuple.NewVector(1, 0, 0).Equals(edge.Direction))
+	assert.True(t, tuple.NewVector(math.Sqrt(2)/2, 0, -math.Sqrt(2)/2).Equals(halfway.Direction))
+}
+
+func TestFisheyeLeavesPointsBeyondFullCircleEmpty(t *testing.T) {
+	c := NewCamera(101, 101, 2*math.Pi)
+	c.SetProjection(Fisheye{})
+
+	assert.Nil(t, c.RayForPixel(0, 0))
+	assert.NotNil(t, c.RayForPixel(0, 50))
+}
+
+func TestEquirectangularCoversEveryDirection(t *testing.T) {
+	c := NewCamera(400, 200, math.Pi/2)
+	c.SetProjection(Equirectangular{})
+	cases := []struct {
+		x, y, dx, dy float64
+		direction    *tuple.Tuple
+	}{
+		{200, 100, 0, 0, tuple.NewVector(0, 0, -1)},
+		{300, 100, 0, 0, tuple.NewVector(-1, 0, 0)},
+		{100, 100, 0, 0, tuple.NewVector(1, 0, 0)},
+		{0, 100, 0, 0, tuple.NewVector(0, 0, 1)},
+		{200, 0, 0, 0, tuple.NewVector(0, 1, 0)},
+		{200, 199, 0, 1, tuple.NewVector(0, -1, 0)},
+	}
+
+	for _, tc := range cases {
+		r := c.RayThroughLens(int(tc.x), int(tc.y), tc.dx, tc.dy, 0.5, 0.5)
+
+		assert.True(t, tc.direction.Equals(r.Direction), "pixel (%v, %v): %v", tc.x, tc.y, r.Direction)
+	}
+}
+
+func TestCubePanoramaFaces(t *testing.T) {
+	c := NewCamera(400, 300, math.Pi/2)
+	c.SetProjection(CubePanorama{})
+	cases := []struct {
+		x, y      int
+		direction *tuple.Tuple
+	}{
+		{150, 50, tuple.NewVector(0, 1, 0)},
+		{50, 150, tuple.NewVector(1, 0, 0)},
+		{150, 150, tuple.NewVector(0, 0, -1)},
+		{250, 150, tuple.NewVector(-1, 0, 0)},
+		{350, 150, tuple.NewVector(0, 0, 1)},
+		{150, 250, tuple.NewVector(0, -1, 0)},
+	}
+
+	for _, tc := range cases {
+		r := c.RayThroughLens(tc.x, tc.y, 0, 0, 0.5, 0.5)
+
+		require.NotNil(t, r)
+		assert.True(t, tc.direction.Equals(r.Direction), "pixel (%v, %v): %v", tc.x, tc.y, r.Direction)
+	}
+
+	assert.Nil(t, c.RayForPixel(50, 50))
+	assert.Nil(t, c.RayForPixel(350, 250))
+}
+
+func TestCubePanoramaFacesMeetAtTheirEdges(t *testing.T) {
+	c := NewCamera(400, 300, math.Pi/2)
+	c.SetProjection(CubePanorama{})
+
+	// the last column of one face and the first of the next look almost the
+	// same way
+	for _, x := range []int{99, 199, 299} {
+		a := c.RayForPixel(x, 120)
+		b := c.RayForPixel(x+1, 120)
+
+		assert.Less(t, a.Direction.Sub(b.Direction).Magnitude(), 0.05, "column %d", x)
+	}
+	up := c.RayForPixel(150, 99)
+	front := c.RayForPixel(150, 100)
+	assert.Less(t, up.Direction.Sub(front.Direction).Magnitude(), 0.05)
+}
+
+func TestRenderingEmptyPartsOfProjectionStaysBlack(t *testing.T) {
+	w := world.NewDefaultWorld()
+	c := NewCamera(16, 12, math.Pi/2)
+	c.SetProjection(CubePanorama{})
+	c.SetTransformation(transformation.ViewTransform(tuple.NewPoint(0, 0, -2), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	im := c.Render(w)
+
+	assert.True(t, im.PixelAt(0, 0).Equals(im.PixelAt(15, 11)))
+	assert.Equal(t, 0.0, im.PixelAt(0, 0).Red)
+	assert.Greater(t, im.PixelAt(5, 5).Red, 0.0)
+}
@@ -0,0 +1,72 @@
+package camera
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+type SamplePattern int
+
+const (
+	// GridSampling places samples at the centers of a regular grid of cells
+	GridSampling SamplePattern = iota
+	// JitteredSampling scatters samples uniformly over the whole pixel
+	JitteredSampling
+	// StratifiedSampling places one randomly offset sample in each grid cell
+	StratifiedSampling
+)
+
+var samplePatternNames = map[string]SamplePattern{
+	"grid":       GridSampling,
+	"jittered":   JitteredSampling,
+	"stratified": StratifiedSampling,
+}
+
+func ParseSamplePattern(name string) (SamplePattern, error) {
+	p, ok := samplePatternNames[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown sample pattern %q", name)
+	}
+
+	return p, nil
+}
+
+// sampleOffsets returns n positions within a pixel, each in [0, 1) along both
+// axes; grid and stratified patterns cover the pixel evenly when n is a
+// perfect square
+func sampleOffsets(pattern SamplePattern, n int, rng *rand.Rand) [][2]float64 {
+	if n < 1 {
+		n = 1
+	}
+
+	cols := int(math.Ceil(math.Sqrt(float64(n))))
+	rows := (n + cols - 1) / cols
+
+	offsets := make([][2]float64, n)
+	for i := range offsets {
+		switch pattern {
+		case JitteredSampling:
+			offsets[i] = [2]float64{rng.Float64(), rng.Float64()}
+		case StratifiedSampling:
+			col, row := i%cols, i/cols
+			offsets[i] = [2]float64{(float64(col) + rng.Float64()) / float64(cols), (float64(row) + rng.Float64()) / float64(rows)}
+		default:
+			col, row := i%cols, i/cols
+			offsets[i] = [2]float64{(float64(col) + 0.5) / float64(cols), (float64(row) + 0.5) / float64(rows)}
+		}
+	}
+
+	return offsets
+}
+
+// rowSeed mixes the camera seed with a row number through splitmix64, so
+// neighbouring seeds don't replay each other's rows shifted by one
+func rowSeed(seed int64, y int) int64 {
+	z := uint64(seed) ^ uint64(y)*0x9e3779b97f4a7c15
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+
+	return int64(z ^ z>>31)
+}
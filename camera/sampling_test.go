@@ -0,0 +1,72 @@
+package camera
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func TestGridSamplingUsesCellCenters(t *testing.T) {
+	offsets := sampleOffsets(GridSampling, 4, nil)
+
+	assert.Equal(t, [][2]float64{{0.25, 0.25}, {0.75, 0.25}, {0.25, 0.75}, {0.75, 0.75}}, offsets)
+}
+
+func TestSingleGridSampleIsPixelCenter(t *testing.T) {
+	assert.Equal(t, [][2]float64{{0.5, 0.5}}, sampleOffsets(GridSampling, 1, nil))
+	assert.Equal(t, [][2]float64{{0.5, 0.5}}, sampleOffsets(GridSampling, 0, nil))
+}
+
+func TestStratifiedSamplingKeepsOneSamplePerCell(t *testing.T) {
+	offsets := sampleOffsets(StratifiedSampling, 9, rand.New(rand.NewSource(1)))
+
+	require.Len(t, offsets, 9)
+	for i, o := range offsets {
+		col, row := i%3, i/3
+		assert.True(t, o[0] >= float64(col)/3 && o[0] < float64(col+1)/3, "sample %d x", i)
+		assert.True(t, o[1] >= float64(row)/3 && o[1] < float64(row+1)/3, "sample %d y", i)
+	}
+}
+
+func TestJitteredSamplingStaysInsidePixel(t *testing.T) {
+	offsets := sampleOffsets(JitteredSampling, 16, rand.New(rand.NewSource(1)))
+
+	require.Len(t, offsets, 16)
+	for _, o := range offsets {
+		assert.True(t, o[0] >= 0 && o[0] < 1)
+		assert.True(t, o[1] >= 0 && o[1] < 1)
+	}
+}
+
+func TestRandomSamplingIsReproducibleForSeed(t *testing.T) {
+	a := sampleOffsets(JitteredSampling, 5, rand.New(rand.NewSource(3)))
+	b := sampleOffsets(JitteredSampling, 5, rand.New(rand.NewSource(3)))
+
+	assert.Equal(t, a, b)
+}
+
+func TestRowSeedsDoNotOverlapAcrossNearbySeeds(t *testing.T) {
+	seen := map[int64]bool{}
+	for seed := int64(0); seed < 8; seed++ {
+		for y := 0; y < 64; y++ {
+			s := rowSeed(seed, y)
+
+			assert.False(t, seen[s], "seed %d row %d", seed, y)
+			seen[s] = true
+		}
+	}
+	assert.Equal(t, rowSeed(5, 7), rowSeed(5, 7))
+}
+
+func TestParsingSamplePatterns(t *testing.T) {
+	for name, expected := range map[string]SamplePattern{"grid": GridSampling, "jittered": JitteredSampling, "stratified": StratifiedSampling} {
+		p, err := ParseSamplePattern(name)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, p)
+	}
+
+	_, err := ParseSamplePattern("poisson")
+	assert.Error(t, err)
+}
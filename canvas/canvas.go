@@ -1,7 +1,6 @@
 package canvas
 
 import (
-	"fmt"
 	"goray/color"
 	"strings"
 )
@@ -40,34 +39,7 @@ func (c *Canvas) WriteAt(x, y int, color *color.Color) {
 
 func (c *Canvas) ToPPM() string {
 	var out strings.Builder
-
-	out.WriteString("P3\n")
-	out.WriteString(fmt.Sprintf("%d %d\n", c.Width, c.Height))
-	out.WriteString("255\n")
-
-	var line strings.Builder
-	for y := range c.Pixels {
-		for x := range c.Pixels[y] {
-			pixel := c.PixelAt(x, y)
-			rgb := pixel.ToRGB()
-
-			for i := range rgb {
-				colorStr := fmt.Sprintf("%d", rgb[i])
-				if line.Len()+1+len(colorStr) > 69 {
-					out.WriteString(line.String())
-					out.WriteString("\n")
-					line.Reset()
-				}
-				if line.Len() != 0 {
-					line.WriteByte(' ')
-				}
-				line.WriteString(colorStr)
-			}
-		}
-		out.WriteString(line.String())
-		out.WriteString("\n")
-		line.Reset()
-	}
+	c.WritePPM(&out)
 
 	return out.String()
 }
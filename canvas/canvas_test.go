@@ -1,9 +1,13 @@
 package canvas
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"goray/color"
+	"image"
+	imagecolor "image/color"
+	"image/png"
 	"strings"
 	"testing"
 )
@@ -85,3 +89,50 @@ func TestPPMEndsWithLineFeed(t *testing.T) {
 
 	require.Equal(t, "\n", ppm[len(ppm)-1:])
 }
+
+func TestWritingPPMStreamsSameOutputAsToPPM(t *testing.T) {
+	canvas := NewCanvas(10, 2)
+	canvas.FillWith(color.NewColor(1, 0.8, 0.6))
+	var out bytes.Buffer
+
+	err := canvas.Encode(&out, PPMText)
+
+	require.NoError(t, err)
+	assert.Equal(t, canvas.ToPPM(), out.String())
+}
+
+func TestWritingBinaryPPM(t *testing.T) {
+	canvas := NewCanvas(2, 1)
+	canvas.WriteAt(0, 0, color.NewColor(1.5, 0, 0.5))
+	canvas.WriteAt(1, 0, color.NewColor(0, 1, -0.5))
+	var out bytes.Buffer
+
+	err := canvas.Encode(&out, PPMBinary)
+
+	require.NoError(t, err)
+	assert.Equal(t, "P6\n2 1\n255\n\xff\x00\x80\x00\xff\x00", out.String())
+}
+
+func TestCanvasAsImage(t *testing.T) {
+	canvas := NewCanvas(3, 2)
+	canvas.WriteAt(2, 1, color.NewColor(1, 0.5, 0))
+
+	var im image.Image = canvas
+
+	assert.Equal(t, image.Rect(0, 0, 3, 2), im.Bounds())
+	assert.Equal(t, imagecolor.RGBA{R: 255, G: 128, B: 0, A: 255}, im.At(2, 1))
+}
+
+func TestWritingPNG(t *testing.T) {
+	canvas := NewCanvas(3, 2)
+	canvas.WriteAt(1, 1, color.NewColor(0, 1, 0))
+	var out bytes.Buffer
+
+	err := canvas.Encode(&out, PNG)
+	require.NoError(t, err)
+
+	decoded, err := png.Decode(&out)
+	require.NoError(t, err)
+	r, g, b, _ := decoded.At(1, 1).RGBA()
+	assert.Equal(t, [3]uint32{0, 0xffff, 0}, [3]uint32{r, g, b})
+}
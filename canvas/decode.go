@@ -0,0 +1,216 @@
+package canvas
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"goray/color"
+	"image"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// maxPPMPixels caps the size a PPM header may announce, since the canvas is
+// allocated before any pixel data is read
+const maxPPMPixels = 8192 * 4096
+
+// Decode reads a P3, P6 or PNG image, picking the format from its signature
+func Decode(r io.Reader) (*Canvas, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(2)
+	if err != nil {
+		return nil, fmt.Errorf("canvas: reading signature: %v", err)
+	}
+	if magic[0] == 'P' {
+		return ReadPPM(br)
+	}
+	if sig, _ := br.Peek(len(pngSignature)); bytes.Equal(sig, pngSignature) {
+		return ReadPNG(br)
+	}
+
+	return nil, errors.New("canvas: unrecognized image format")
+}
+
+func ReadPNG(r io.Reader) (*Canvas, error) {
+	im, err := png.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("canvas: %v", err)
+	}
+
+	return FromImage(im), nil
+}
+
+func FromImage(im image.Image) *Canvas {
+	b := im.Bounds()
+	c := NewCanvas(b.Dx(), b.Dy())
+
+	for y := 0; y < c.Height; y++ {
+		for x := 0; x < c.Width; x++ {
+			r, g, bl, _ := im.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			c.Pixels[y][x] = color.NewColor(float64(r)/0xffff, float64(g)/0xffff, float64(bl)/0xffff)
+		}
+	}
+
+	return c
+}
+
+func ReadPPM(r io.Reader) (*Canvas, error) {
+	p := &ppmReader{r: bufio.NewReader(r)}
+
+	magic, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+	if magic != "P3" && magic != "P6" {
+		return nil, fmt.Errorf("canvas: unsupported PPM magic number %q", magic)
+	}
+
+	width, err := p.int("width", 1, 1<<16)
+	if err != nil {
+		return nil, err
+	}
+	height, err := p.int("height", 1, 1<<16)
+	if err != nil {
+		return nil, err
+	}
+	if width*height > maxPPMPixels {
+		return nil, fmt.Errorf("canvas: PPM size %dx%d exceeds %d pixels", width, height, maxPPMPixels)
+	}
+	maxValue, err := p.int("max value", 1, 65535)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCanvas(width, height)
+	scale := float64(maxValue)
+
+	read := p.textSample
+	if magic == "P6" {
+		// exactly one whitespace character separates the header from the raster
+		if _, err := p.r.ReadByte(); err != nil {
+			return nil, fmt.Errorf("canvas: reading raster: %v", err)
+		}
+		read = p.binarySample
+		if maxValue > 255 {
+			read = p.wideBinarySample
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var rgb [3]float64
+			for i := range rgb {
+				v, err := read(maxValue)
+				if err != nil {
+					return nil, fmt.Errorf("canvas: pixel (%d, %d): %v", x, y, err)
+				}
+				rgb[i] = float64(v) / scale
+			}
+			c.Pixels[y][x] = color.NewColor(rgb[0], rgb[1], rgb[2])
+		}
+	}
+
+	return c, nil
+}
+
+type ppmReader struct {
+	r *bufio.Reader
+}
+
+// token returns the next whitespace-separated word, skipping comments that
+// run from '#' to the end of the line
+func (p *ppmReader) token() (string, error) {
+	var tok []byte
+
+	for {
+		b, err := p.r.ReadByte()
+		if err == io.EOF && len(tok) > 0 {
+			return string(tok), nil
+		}
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", fmt.Errorf("canvas: %v", err)
+		}
+
+		switch {
+		case b == '#':
+			if _, err := p.r.ReadString('\n'); err != nil && err != io.EOF {
+				return "", fmt.Errorf("canvas: %v", err)
+			}
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		case isSpace(b):
+			if len(tok) > 0 {
+				return string(tok), p.r.UnreadByte()
+			}
+		default:
+			tok = append(tok, b)
+		}
+	}
+}
+
+func (p *ppmReader) int(name string, min, max int) (int, error) {
+	tok, err := p.token()
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.Atoi(tok)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("canvas: invalid %s %q", name, tok)
+	}
+
+	return v, nil
+}
+
+func (p *ppmReader) textSample(maxValue int) (int, error) {
+	tok, err := p.token()
+	if err != nil {
+		return 0, err
+	}
+
+	v, err := strconv.Atoi(tok)
+	if err != nil || v < 0 || v > maxValue {
+		return 0, fmt.Errorf("invalid sample %q", tok)
+	}
+
+	return v, nil
+}
+
+func (p *ppmReader) binarySample(maxValue int) (int, error) {
+	b, err := p.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if int(b) > maxValue {
+		return 0, fmt.Errorf("sample %d exceeds max value %d", b, maxValue)
+	}
+
+	return int(b), nil
+}
+
+func (p *ppmReader) wideBinarySample(maxValue int) (int, error) {
+	var buf [2]byte
+	if _, err := io.ReadFull(p.r, buf[:]); err != nil {
+		return 0, err
+	}
+
+	v := int(buf[0])<<8 | int(buf[1])
+	if v > maxValue {
+		return 0, fmt.Errorf("sample %d exceeds max value %d", v, maxValue)
+	}
+
+	return v, nil
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f'
+}
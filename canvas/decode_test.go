@@ -0,0 +1,163 @@
+package canvas
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/color"
+	"strings"
+	"testing"
+)
+
+func TestReadingPPMWithWrongMagicNumber(t *testing.T) {
+	_, err := ReadPPM(strings.NewReader("P32\n1 1\n255\n0 0 0\n"))
+
+	assert.Error(t, err)
+}
+
+func TestReadingPPMReturnsCanvasOfRightSize(t *testing.T) {
+	c, err := ReadPPM(strings.NewReader("P3\n10 2\n255\n" + strings.Repeat("0 0 0 ", 20)))
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, c.Width)
+	assert.Equal(t, 2, c.Height)
+}
+
+func TestReadingPixelDataFromPPM(t *testing.T) {
+	ppm := `P3
+4 3
+255
+255 127 0  0 127 255  127 255 0  255 255 255
+0 0 0  255 0 0  0 255 0  0 0 255
+255 255 0  0 255 255  255 0 255  127 127 127
+`
+	c, err := ReadPPM(strings.NewReader(ppm))
+	require.NoError(t, err)
+
+	cases := []struct {
+		x, y     int
+		expected *color.Color
+	}{
+		{0, 0, color.NewColor(1, 0.49804, 0)},
+		{1, 0, color.NewColor(0, 0.49804, 1)},
+		{2, 0, color.NewColor(0.49804, 1, 0)},
+		{3, 0, color.NewColor(1, 1, 1)},
+		{0, 1, color.NewColor(0, 0, 0)},
+		{1, 1, color.NewColor(1, 0, 0)},
+		{2, 1, color.NewColor(0, 1, 0)},
+		{3, 1, color.NewColor(0, 0, 1)},
+		{0, 2, color.NewColor(1, 1, 0)},
+		{1, 2, color.NewColor(0, 1, 1)},
+		{2, 2, color.NewColor(1, 0, 1)},
+		{3, 2, color.NewColor(0.49804, 0.49804, 0.49804)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(c.PixelAt(tc.x, tc.y)), "pixel (%d, %d)", tc.x, tc.y)
+	}
+}
+
+func TestReadingPPMIgnoresComments(t *testing.T) {
+	ppm := `P3
+# this is a comment
+2 1
+# this, too
+255
+# another comment
+255 255 255
+# oh, no, comments in the pixel data!
+255 0 255
+`
+	c, err := ReadPPM(strings.NewReader(ppm))
+	require.NoError(t, err)
+
+	assert.True(t, color.NewColor(1, 1, 1).Equals(c.PixelAt(0, 0)))
+	assert.True(t, color.NewColor(1, 0, 1).Equals(c.PixelAt(1, 0)))
+}
+
+func TestReadingPPMAllowsRGBTriplesToSpanLines(t *testing.T) {
+	ppm := "P3\n1 1\n255\n51\n153\n\n204\n"
+
+	c, err := ReadPPM(strings.NewReader(ppm))
+	require.NoError(t, err)
+
+	assert.True(t, color.NewColor(0.2, 0.6, 0.8).Equals(c.PixelAt(0, 0)))
+}
+
+func TestReadingPPMRespectsMaxValue(t *testing.T) {
+	c, err := ReadPPM(strings.NewReader("P3\t2 1 100\r\n100 100 100 50 50 50"))
+	require.NoError(t, err)
+
+	assert.True(t, color.NewColor(1, 1, 1).Equals(c.PixelAt(0, 0)))
+	assert.True(t, color.NewColor(0.5, 0.5, 0.5).Equals(c.PixelAt(1, 0)))
+}
+
+func TestReadingBinaryPPM(t *testing.T) {
+	c, err := ReadPPM(strings.NewReader("P6 # comment\n2 1\n255\n\xff\x00\x33\x00\x99\xff"))
+	require.NoError(t, err)
+
+	assert.True(t, color.NewColor(1, 0, 0.2).Equals(c.PixelAt(0, 0)))
+	assert.True(t, color.NewColor(0, 0.6, 1).Equals(c.PixelAt(1, 0)))
+}
+
+func TestReadingBinaryPPMWithTwoByteSamples(t *testing.T) {
+	c, err := ReadPPM(strings.NewReader("P6\n1 1\n1000\n\x03\xe8\x01\xf4\x00\x00"))
+	require.NoError(t, err)
+
+	assert.True(t, color.NewColor(1, 0.5, 0).Equals(c.PixelAt(0, 0)))
+}
+
+func TestReadingTruncatedOrInvalidPPM(t *testing.T) {
+	cases := []string{
+		"",
+		"P3\n2 1\n255\n0 0 0",
+		"P3\n1 1\n255\n0 0 256",
+		"P3\n1 1\n255\n0 x 0",
+		"P3\n-1 1\n255\n",
+		"P3\n1 1\n0\n0 0 0",
+		"P6\n2 1\n255\n\x00\x00\x00\x00",
+		"P6\n1 1\n100\n\xff\x00\x00",
+	}
+
+	for _, ppm := range cases {
+		_, err := ReadPPM(strings.NewReader(ppm))
+
+		assert.Error(t, err, "%q", ppm)
+	}
+}
+
+func TestReadingPPMWithOversizedHeaderFailsBeforeAllocating(t *testing.T) {
+	cases := map[string]string{
+		"P6\n65536 65536\n255\n": "canvas: PPM size 65536x65536 exceeds 33554432 pixels",
+		"P3\n8193 4096\n255\n":   "canvas: PPM size 8193x4096 exceeds 33554432 pixels",
+	}
+
+	for ppm, message := range cases {
+		_, err := ReadPPM(strings.NewReader(ppm))
+
+		assert.EqualError(t, err, message)
+	}
+}
+
+func TestRoundTrippingThroughEveryFormat(t *testing.T) {
+	original := NewCanvas(5, 3)
+	original.WriteAt(0, 0, color.NewColor(1, 0, 0))
+	original.WriteAt(4, 2, color.NewColor(0.2, 0.4, 0.6))
+	original.WriteAt(2, 1, color.NewColor(1, 1, 1))
+
+	for _, f := range []Format{PPMText, PPMBinary, PNG} {
+		var buf bytes.Buffer
+		require.NoError(t, original.Encode(&buf, f))
+
+		decoded, err := Decode(&buf)
+		require.NoError(t, err, "format %d", f)
+
+		assert.Equal(t, original.ToPPM(), decoded.ToPPM(), "format %d", f)
+	}
+}
+
+func TestDecodingUnknownFormat(t *testing.T) {
+	_, err := Decode(strings.NewReader("GIF89a"))
+
+	assert.Error(t, err)
+}
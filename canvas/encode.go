@@ -0,0 +1,98 @@
+package canvas
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	imagecolor "image/color"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+type Format int
+
+const (
+	PPMText Format = iota
+	PPMBinary
+	PNG
+)
+
+const ppmLineLength = 70
+
+func (c *Canvas) Encode(w io.Writer, f Format) error {
+	switch f {
+	case PPMText:
+		return c.WritePPM(w)
+	case PPMBinary:
+		return c.WritePPMBinary(w)
+	case PNG:
+		return c.WritePNG(w)
+	}
+
+	return fmt.Errorf("canvas: unknown format %d", f)
+}
+
+// WritePPM streams the canvas as plain-text P3, wrapping lines so that none
+// exceeds 70 characters
+func (c *Canvas) WritePPM(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P3\n%d %d\n255\n", c.Width, c.Height)
+
+	var buf []byte
+	for y := range c.Pixels {
+		lineLen := 0
+		for x := range c.Pixels[y] {
+			rgb := c.PixelAt(x, y).ToRGB()
+
+			for i := range rgb {
+				buf = strconv.AppendInt(buf[:0], int64(rgb[i]), 10)
+				if lineLen+1+len(buf) > ppmLineLength-1 {
+					bw.WriteByte('\n')
+					lineLen = 0
+				}
+				if lineLen != 0 {
+					bw.WriteByte(' ')
+					lineLen++
+				}
+				bw.Write(buf)
+				lineLen += len(buf)
+			}
+		}
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+func (c *Canvas) WritePPMBinary(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "P6\n%d %d\n255\n", c.Width, c.Height)
+
+	for y := range c.Pixels {
+		for x := range c.Pixels[y] {
+			rgb := c.PixelAt(x, y).ToRGB()
+			bw.Write([]byte{byte(rgb[0]), byte(rgb[1]), byte(rgb[2])})
+		}
+	}
+
+	return bw.Flush()
+}
+
+func (c *Canvas) WritePNG(w io.Writer) error {
+	return png.Encode(w, c)
+}
+
+func (c *Canvas) ColorModel() imagecolor.Model {
+	return imagecolor.RGBAModel
+}
+
+func (c *Canvas) Bounds() image.Rectangle {
+	return image.Rect(0, 0, c.Width, c.Height)
+}
+
+func (c *Canvas) At(x, y int) imagecolor.Color {
+	rgb := c.PixelAt(x, y).ToRGB()
+
+	return imagecolor.RGBA{R: uint8(rgb[0]), G: uint8(rgb[1]), B: uint8(rgb[2]), A: 255}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"goray/camera"
+	"goray/canvas"
+	"goray/scene"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+const (
+	exitOK = iota
+	exitRenderError
+	exitUsage
+	exitSceneError
+)
+
+var formats = map[string]canvas.Format{
+	"ppm": canvas.PPMText,
+	"p3":  canvas.PPMText,
+	"p6":  canvas.PPMBinary,
+	"png": canvas.PNG,
+}
+
+type options struct {
+	scenePath string
+	output    string
+	format    canvas.Format
+	width     int
+	height    int
+	workers   int
+	samples   int
+	sampling  camera.SamplePattern
+	seed      int64
+
+	adaptive      float64
+	adaptiveDepth int
+	sampleCounts  string
+
+	// set holds the flags given on the command line; camera settings the
+	// scene may also choose are only overridden when their flag is set
+	set map[string]bool
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	opts, err := parseFlags(args, stderr)
+	if err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		fmt.Fprintln(stderr, "goray:", err)
+		return exitUsage
+	}
+
+	s, err := scene.LoadFile(opts.scenePath)
+	if err != nil {
+		fmt.Fprintln(stderr, "goray:", err)
+		return exitSceneError
+	}
+	for _, warning := range s.Warnings {
+		fmt.Fprintln(stderr, "goray: warning:", warning)
+	}
+
+	c := configureCamera(s.Camera, opts)
+	if opts.sampleCounts == "" {
+		if err := writeImage(c.Render(s.World), opts.output, opts.format, stdout); err != nil {
+			fmt.Fprintln(stderr, "goray:", err)
+			return exitRenderError
+		}
+		return exitOK
+	}
+
+	im, counts := c.RenderWithSampleCounts(s.World)
+	if err := writeImage(im, opts.output, opts.format, stdout); err != nil {
+		fmt.Fprintln(stderr, "goray:", err)
+		return exitRenderError
+	}
+	if err := writeImage(counts, opts.sampleCounts, opts.format, stdout); err != nil {
+		fmt.Fprintln(stderr, "goray:", err)
+		return exitRenderError
+	}
+
+	return exitOK
+}
+
+func parseFlags(args []string, stderr io.Writer) (*options, error) {
+	opts := &options{set: map[string]bool{}}
+
+	fs := flag.NewFlagSet("goray", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: goray [flags] scene.yml")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.output, "o", "-", "output file, - for stdout")
+	format := fs.String("format", "ppm", "output format: ppm (or p3), p6, png")
+	fs.IntVar(&opts.width, "width", 0, "override the scene's horizontal resolution")
+	fs.IntVar(&opts.height, "height", 0, "override the scene's vertical resolution")
+	fs.IntVar(&opts.workers, "workers", runtime.NumCPU(), "number of render goroutines")
+	fs.IntVar(&opts.samples, "samples", 1, "samples per pixel, overriding the scene")
+	sampling := fs.String("sampling", "", "sample placement within a pixel, overriding the scene: grid, jittered, stratified")
+	fs.Int64Var(&opts.seed, "seed", 0, "seed for random sample placement")
+	fs.Float64Var(&opts.adaptive, "adaptive", 0, "contrast threshold for adaptive supersampling, 0 to disable")
+	fs.IntVar(&opts.adaptiveDepth, "adaptive-depth", camera.DefaultAdaptiveDepth, "maximum number of times adaptive supersampling subdivides a pixel")
+	fs.StringVar(&opts.sampleCounts, "sample-counts", "", "also write a debug image of samples taken per pixel to this file")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	fs.Visit(func(f *flag.Flag) { opts.set[f.Name] = true })
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return nil, fmt.Errorf("expected exactly one scene file, got %d", fs.NArg())
+	}
+	opts.scenePath = fs.Arg(0)
+
+	f, ok := formats[*format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %q", *format)
+	}
+	opts.format = f
+	if opts.width < 0 || opts.height < 0 {
+		return nil, fmt.Errorf("resolution must not be negative")
+	}
+	if opts.workers < 1 {
+		return nil, fmt.Errorf("workers must be at least 1")
+	}
+	if opts.samples < 1 {
+		return nil, fmt.Errorf("samples must be at least 1")
+	}
+	if opts.adaptive < 0 || opts.adaptiveDepth < 0 {
+		return nil, fmt.Errorf("adaptive threshold and depth must not be negative")
+	}
+	if opts.set["sampling"] {
+		pattern, err := camera.ParseSamplePattern(*sampling)
+		if err != nil {
+			return nil, err
+		}
+		opts.sampling = pattern
+	}
+
+	return opts, nil
+}
+
+// configureCamera applies command line overrides to the scene's camera
+func configureCamera(c *camera.Camera, opts *options) *camera.Camera {
+	width, height := c.HSize, c.VSize
+	if opts.width > 0 {
+		width = opts.width
+	}
+	if opts.height > 0 {
+		height = opts.height
+	}
+	if width != c.HSize || height != c.VSize {
+		c.Resize(width, height)
+	}
+
+	c.Workers = opts.workers
+	if opts.set["samples"] {
+		c.Samples = opts.samples
+	}
+	if opts.set["sampling"] {
+		c.SamplePattern = opts.sampling
+	}
+	if opts.set["seed"] {
+		c.Seed = opts.seed
+	}
+	if opts.set["adaptive"] {
+		c.AdaptiveThreshold = opts.adaptive
+	}
+	if opts.set["adaptive-depth"] {
+		c.AdaptiveDepth = opts.adaptiveDepth
+	}
+
+	return c
+}
+
+// writeImage encodes into a temporary file next to path and renames it into
+// place, so a failed encode never leaves a truncated image behind
+func writeImage(im *canvas.Canvas, path string, format canvas.Format, stdout io.Writer) error {
+	if path == "-" {
+		return im.Encode(stdout, format)
+	}
+
+	f, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	err = f.Chmod(0644)
+	if err == nil {
+		err = im.Encode(f, format)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+
+	return err
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/camera"
+	"goray/canvas"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testScene = `- add: camera
+  width: 8
+  height: 4
+  field-of-view: 1.0471975512
+  from: [0, 1.5, -5]
+  to: [0, 1, 0]
+  up: [0, 1, 0]
+
+- add: light
+  at: [-10, 10, -10]
+  intensity: [1, 1, 1]
+
+- add: sphere
+`
+
+func writeScene(t *testing.T, dir, contents string) string {
+	path := filepath.Join(dir, "scene.yml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+
+	return path
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "goray")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
+
+func TestRenderingSceneToStdout(t *testing.T) {
+	path := writeScene(t, tempDir(t), testScene)
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{path}, &stdout, &stderr)
+
+	assert.Equal(t, exitOK, code)
+	assert.True(t, strings.HasPrefix(stdout.String(), "P3\n8 4\n255\n"))
+	assert.Empty(t, stderr.String())
+}
+
+func TestSceneWarningsArePrinted(t *testing.T) {
+	dir := tempDir(t)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "model.obj"), []byte("v 0 0 0\nv 1 0 0\nv 0 1 0\ng top\nf 1 2 3\ns off\n"), 0644))
+	path := writeScene(t, dir, testScene+`
+- add: obj
+  file: model.obj
+`)
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{path}, &stdout, &stderr)
+
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "goray: warning: scene: line 16: file: ignored 1 unrecognised lines in model.obj\n", stderr.String())
+}
+
+func TestRenderingSceneToFileWithResolutionOverride(t *testing.T) {
+	dir := tempDir(t)
+	path := writeScene(t, dir, testScene)
+	output := filepath.Join(dir, "out.ppm")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-o", output, "-width", "6", "-height", "3", "-workers", "2", path}, &stdout, &stderr)
+
+	require.Equal(t, exitOK, code)
+	data, err := ioutil.ReadFile(output)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "P3\n6 3\n255\n"))
+	assert.Empty(t, stdout.String())
+}
+
+func TestRenderingSceneInOtherFormats(t *testing.T) {
+	path := writeScene(t, tempDir(t), testScene)
+	cases := map[string]string{
+		"p3":  "P3\n8 4\n255\n",
+		"p6":  "P6\n8 4\n255\n",
+		"png": "\x89PNG\r\n\x1a\n",
+	}
+
+	for format, prefix := range cases {
+		var stdout, stderr bytes.Buffer
+
+		code := run([]string{"-format", format, path}, &stdout, &stderr)
+
+		require.Equal(t, exitOK, code, format)
+		assert.True(t, strings.HasPrefix(stdout.String(), prefix), format)
+	}
+}
+
+func TestRenderingWithSupersamplingIsReproducible(t *testing.T) {
+	path := writeScene(t, tempDir(t), testScene)
+	args := []string{"-samples", "4", "-sampling", "jittered", "-seed", "9", path}
+	var first, second, stderr bytes.Buffer
+
+	require.Equal(t, exitOK, run(args, &first, &stderr))
+	require.Equal(t, exitOK, run(args, &second, &stderr))
+
+	assert.Equal(t, first.String(), second.String())
+	assert.True(t, strings.HasPrefix(first.String(), "P3\n8 4\n255\n"))
+}
+
+func TestWritingAdaptiveSampleCounts(t *testing.T) {
+	dir := tempDir(t)
+	path := writeScene(t, dir, testScene)
+	counts := filepath.Join(dir, "counts.ppm")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-adaptive", "0.1", "-adaptive-depth", "2", "-sample-counts", counts, path}, &stdout, &stderr)
+
+	require.Equal(t, exitOK, code, stderr.String())
+	data, err := ioutil.ReadFile(counts)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "P3\n8 4\n255\n"))
+	assert.True(t, strings.HasPrefix(stdout.String(), "P3\n8 4\n255\n"))
+}
+
+func TestSceneSamplingIsKeptUnlessOverridden(t *testing.T) {
+	c := camera.NewCamera(8, 4, 1)
+	c.Samples = 9
+	c.SamplePattern = camera.JitteredSampling
+
+	opts, err := parseFlags([]string{"scene.yml"}, ioutil.Discard)
+	require.NoError(t, err)
+	configureCamera(c, opts)
+
+	assert.Equal(t, 9, c.Samples)
+	assert.Equal(t, camera.JitteredSampling, c.SamplePattern)
+
+	opts, err = parseFlags([]string{"-samples", "2", "scene.yml"}, ioutil.Discard)
+	require.NoError(t, err)
+	configureCamera(c, opts)
+
+	assert.Equal(t, 2, c.Samples)
+	assert.Equal(t, camera.JitteredSampling, c.SamplePattern)
+}
+
+func TestHelpDoesNotClaimSamplingDefault(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-h"}, &stdout, &stderr)
+
+	assert.Equal(t, exitOK, code)
+	assert.Contains(t, stderr.String(), "-sampling string")
+	assert.NotContains(t, stderr.String(), `(default "stratified")`)
+}
+
+func TestUsageErrors(t *testing.T) {
+	path := writeScene(t, tempDir(t), testScene)
+	cases := [][]string{
+		{},
+		{path, path},
+		{"-format", "gif", path},
+		{"-workers", "0", path},
+		{"-samples", "0", path},
+		{"-sampling", "poisson", path},
+		{"-adaptive", "-0.1", path},
+		{"-width", "-1", path},
+		{"-bogus", path},
+	}
+
+	for _, args := range cases {
+		var stdout, stderr bytes.Buffer
+
+		code := run(args, &stdout, &stderr)
+
+		assert.Equal(t, exitUsage, code, "args: %v", args)
+		assert.NotEmpty(t, stderr.String())
+	}
+}
+
+func TestMalformedSceneExitsWithSceneError(t *testing.T) {
+	path := writeScene(t, tempDir(t), testScene+"- add: teapot\n")
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{path}, &stdout, &stderr)
+
+	assert.Equal(t, exitSceneError, code)
+	assert.Contains(t, stderr.String(), "line 14")
+}
+
+func TestMissingSceneExitsWithSceneError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{filepath.Join(tempDir(t), "missing.yml")}, &stdout, &stderr)
+
+	assert.Equal(t, exitSceneError, code)
+}
+
+func TestUnwritableOutputExitsWithRenderError(t *testing.T) {
+	dir := tempDir(t)
+	path := writeScene(t, dir, testScene)
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-o", filepath.Join(dir, "missing", "out.ppm"), path}, &stdout, &stderr)
+
+	assert.Equal(t, exitRenderError, code)
+}
+
+func TestFailedEncodeLeavesExistingOutputAlone(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "out.ppm")
+	require.NoError(t, ioutil.WriteFile(path, []byte("previous render"), 0644))
+
+	err := writeImage(canvas.NewCanvas(2, 2), path, canvas.Format(-1), nil)
+
+	assert.Error(t, err)
+	contents, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "previous render", string(contents))
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestExampleScenesLoad(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := run([]string{"-width", "4", "-height", "2", "../../scenes/chapter9.yml"}, &stdout, &stderr)
+
+	assert.Equal(t, exitOK, code, stderr.String())
+}
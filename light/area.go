@@ -0,0 +1,58 @@
+package light
+
+import (
+	"goray/color"
+	"goray/tuple"
+)
+
+// AreaLight is a rectangular grid of USteps x VSteps cells spanning UVec and
+// VVec from Corner, sampled once per cell; Jittered lights move each sample
+// to a random spot in its cell, which softens banding in shadows
+type AreaLight struct {
+	Corner         *tuple.Tuple
+	UVec, VVec     *tuple.Tuple
+	USteps, VSteps int
+	Jittered       bool
+}
+
+func NewAreaLight(corner, fullUVec *tuple.Tuple, uSteps int, fullVVec *tuple.Tuple, vSteps int, intensity *color.Color) *Light {
+	return NewLight(&AreaLight{
+		Corner: corner,
+		UVec:   fullUVec.Divide(float64(uSteps)),
+		VVec:   fullVVec.Divide(float64(vSteps)),
+		USteps: uSteps,
+		VSteps: vSteps,
+	}, intensity)
+}
+
+// NewJitteredAreaLight is NewAreaLight with every sample moved to a random
+// spot within its cell
+func NewJitteredAreaLight(corner, fullUVec *tuple.Tuple, uSteps int, fullVVec *tuple.Tuple, vSteps int, intensity *color.Color) *Light {
+	l := NewAreaLight(corner, fullUVec, uSteps, fullVVec, vSteps, intensity)
+	l.lightType.(*AreaLight).Jittered = true
+
+	return l
+}
+
+// PointOnLight picks a point inside cell (u, v): its center, or a spot
+// drawn from jitter when the light is jittered and jitter is not nil
+func (al *AreaLight) PointOnLight(u, v int, jitter Jitter) *tuple.Tuple {
+	du, dv := 0.5, 0.5
+	if al.Jittered && jitter != nil {
+		du, dv = jitter.Float64(), jitter.Float64()
+	}
+
+	return al.Corner.Add(al.UVec.Multiply(float64(u) + du)).Add(al.VVec.Multiply(float64(v) + dv))
+}
+
+func (al *AreaLight) samples() int {
+	return al.USteps * al.VSteps
+}
+
+func (al *AreaLight) directionFrom(point *tuple.Tuple, sample int, jitter Jitter) (*tuple.Tuple, float64) {
+	return directionTo(al.PointOnLight(sample%al.USteps, sample/al.USteps, jitter), point)
+}
+
+func (al *AreaLight) falloff(point *tuple.Tuple) float64 {
+	return 1
+}
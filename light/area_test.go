@@ -0,0 +1,92 @@
+package light
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/tuple"
+	"testing"
+)
+
+func newTestAreaLight() *AreaLight {
+	l := NewAreaLight(tuple.NewPoint(0, 0, 0), tuple.NewVector(2, 0, 0), 4, tuple.NewVector(0, 0, 1), 2, color.NewColor(1, 1, 1))
+
+	return l.lightType.(*AreaLight)
+}
+
+func TestCreatingAreaLight(t *testing.T) {
+	corner := tuple.NewPoint(0, 0, 0)
+
+	light := NewAreaLight(corner, tuple.NewVector(2, 0, 0), 4, tuple.NewVector(0, 0, 1), 2, color.NewColor(1, 1, 1))
+	al := light.lightType.(*AreaLight)
+
+	assert.Equal(t, corner, al.Corner)
+	assert.True(t, tuple.NewVector(0.5, 0, 0).Equals(al.UVec))
+	assert.Equal(t, 4, al.USteps)
+	assert.True(t, tuple.NewVector(0, 0, 0.5).Equals(al.VVec))
+	assert.Equal(t, 2, al.VSteps)
+	assert.Equal(t, 8, light.Samples())
+}
+
+func TestFindingSinglePointOnAreaLight(t *testing.T) {
+	al := newTestAreaLight()
+
+	cases := []struct {
+		u, v     int
+		expected *tuple.Tuple
+	}{
+		{0, 0, tuple.NewPoint(0.25, 0, 0.25)},
+		{1, 0, tuple.NewPoint(0.75, 0, 0.25)},
+		{0, 1, tuple.NewPoint(0.25, 0, 0.75)},
+		{2, 0, tuple.NewPoint(1.25, 0, 0.25)},
+		{3, 1, tuple.NewPoint(1.75, 0, 0.75)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(al.PointOnLight(tc.u, tc.v, NewSequence(0.3, 0.7))), "cell (%d, %d)", tc.u, tc.v)
+	}
+}
+
+func TestFindingSinglePointOnJitteredAreaLight(t *testing.T) {
+	al := newTestAreaLight()
+	al.Jittered = true
+	jitter := NewSequence(0.3, 0.7)
+
+	cases := []struct {
+		u, v     int
+		expected *tuple.Tuple
+	}{
+		{0, 0, tuple.NewPoint(0.15, 0, 0.35)},
+		{1, 0, tuple.NewPoint(0.65, 0, 0.35)},
+		{0, 1, tuple.NewPoint(0.15, 0, 0.85)},
+		{2, 0, tuple.NewPoint(1.15, 0, 0.35)},
+		{3, 1, tuple.NewPoint(1.65, 0, 0.85)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(al.PointOnLight(tc.u, tc.v, jitter)), "cell (%d, %d)", tc.u, tc.v)
+	}
+}
+
+func TestAreaLightSamplesWalkCellsRowByRow(t *testing.T) {
+	light := NewAreaLight(tuple.NewPoint(0, 0, 0), tuple.NewVector(2, 0, 0), 4, tuple.NewVector(0, 0, 1), 2, color.NewColor(1, 1, 1))
+	point := tuple.NewPoint(1.75, -2, 0.75)
+
+	direction, distance := light.DirectionFrom(point, 7)
+
+	assert.True(t, tuple.NewVector(0, 1, 0).Equals(direction))
+	assert.Equal(t, 2.0, distance)
+}
+
+func TestSamplesFromJitteredAreaLight(t *testing.T) {
+	light := NewJitteredAreaLight(tuple.NewPoint(0, 0, 0), tuple.NewVector(2, 0, 0), 4, tuple.NewVector(0, 0, 1), 2, color.NewColor(1, 1, 1))
+	point := tuple.NewPoint(1.75, -2, 0.75)
+
+	centred := light.SamplesFrom(point, nil)
+	jittered := light.SamplesFrom(point, NewSequence(0.5, 0.5, 0.3, 0.7))
+
+	assert.Len(t, centred, 8)
+	assert.Equal(t, centred[0], jittered[0])
+	assert.Equal(t, 2.0, centred[7].Distance)
+	assert.True(t, tuple.NewVector(0, 1, 0).Equals(centred[7].Direction))
+	assert.NotEqual(t, centred[1], jittered[1])
+}
@@ -0,0 +1,29 @@
+package light
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+// DirectionalLight is infinitely far away, like the sun: every point receives
+// it from the same direction and nothing is ever behind it
+type DirectionalLight struct {
+	Direction *tuple.Tuple
+}
+
+func NewDirectionalLight(direction *tuple.Tuple, intensity *color.Color) *Light {
+	return NewLight(&DirectionalLight{Direction: direction.Normalize()}, intensity)
+}
+
+func (dl *DirectionalLight) samples() int {
+	return 1
+}
+
+func (dl *DirectionalLight) directionFrom(point *tuple.Tuple, sample int, jitter Jitter) (*tuple.Tuple, float64) {
+	return dl.Direction.Negate(), math.Inf(1)
+}
+
+func (dl *DirectionalLight) falloff(point *tuple.Tuple) float64 {
+	return 1
+}
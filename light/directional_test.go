@@ -0,0 +1,20 @@
+package light
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestDirectionalLightComesFromSameDirectionEverywhere(t *testing.T) {
+	light := NewDirectionalLight(tuple.NewVector(0, -3, 0), color.NewColor(1, 1, 1))
+
+	for _, p := range []*tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(100, -50, 7)} {
+		direction, distance := light.DirectionFrom(p, 0)
+
+		assert.True(t, tuple.NewVector(0, 1, 0).Equals(direction))
+		assert.True(t, math.IsInf(distance, 1))
+	}
+}
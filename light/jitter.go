@@ -0,0 +1,26 @@
+package light
+
+// Jitter yields offsets in [0, 1) used to place samples within a light's
+// cells. *rand.Rand satisfies it, so renders can pass the generator each
+// worker already owns and stay reproducible however the work is split
+type Jitter interface {
+	Float64() float64
+}
+
+// Sequence cycles through a fixed list of offsets, which keeps tests
+// deterministic
+type Sequence struct {
+	values []float64
+	next   int
+}
+
+func NewSequence(values ...float64) *Sequence {
+	return &Sequence{values: values}
+}
+
+func (s *Sequence) Float64() float64 {
+	v := s.values[s.next]
+	s.next = (s.next + 1) % len(s.values)
+
+	return v
+}
@@ -5,11 +5,65 @@ import (
 	"goray/tuple"
 )
 
+type lightType interface {
+	samples() int
+	directionFrom(point *tuple.Tuple, sample int, jitter Jitter) (*tuple.Tuple, float64)
+	falloff(point *tuple.Tuple) float64
+}
+
 type Light struct {
-	Position  *tuple.Tuple
 	Intensity *color.Color
+	lightType lightType
 }
 
-func NewPointLight(position *tuple.Tuple, intensity *color.Color) *Light {
-	return &Light{Position: position, Intensity: intensity}
+func NewLight(lightType lightType, intensity *color.Color) *Light {
+	return &Light{Intensity: intensity, lightType: lightType}
+}
+
+// Samples is the number of points shading and shadow tests average over
+func (l *Light) Samples() int {
+	return l.lightType.samples()
+}
+
+// DirectionFrom returns the unit vector from point towards the given sample
+// of the light and the distance to it, which is infinite for lights that
+// have no position. Area light samples sit at the centre of their cells
+func (l *Light) DirectionFrom(point *tuple.Tuple, sample int) (*tuple.Tuple, float64) {
+	return l.lightType.directionFrom(point, sample, nil)
+}
+
+// Sample is the direction and distance from a point to one sample of a light
+type Sample struct {
+	Direction *tuple.Tuple
+	Distance  float64
+}
+
+// SamplesFrom picks every sample of the light as seen from point. Jittered
+// area lights draw their offsets from jitter, or use cell centres when it is
+// nil; shading and shadow tests share the result so they agree on where the
+// light is
+func (l *Light) SamplesFrom(point *tuple.Tuple, jitter Jitter) []Sample {
+	samples := make([]Sample, l.lightType.samples())
+	for i := range samples {
+		samples[i].Direction, samples[i].Distance = l.lightType.directionFrom(point, i, jitter)
+	}
+
+	return samples
+}
+
+// IntensityAt is the light's color arriving at point, ignoring shadows
+func (l *Light) IntensityAt(point *tuple.Tuple) *color.Color {
+	f := l.lightType.falloff(point)
+	if f == 1 {
+		return l.Intensity
+	}
+
+	return l.Intensity.MultiplyScalar(f)
+}
+
+func directionTo(target, point *tuple.Tuple) (*tuple.Tuple, float64) {
+	v := target.Sub(point)
+	distance := v.Magnitude()
+
+	return v.Divide(distance), distance
 }
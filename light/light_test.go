@@ -13,6 +13,47 @@ func TestPointLightHasPositionAndIntensity(t *testing.T) {
 
 	light := NewPointLight(position, intensity)
 
-	assert.Equal(t, position, light.Position)
+	assert.Equal(t, position, light.lightType.(*PointLight).Position)
 	assert.Equal(t, intensity, light.Intensity)
 }
+
+func TestPointLightIsSingleSample(t *testing.T) {
+	light := NewPointLight(tuple.NewPoint(0, 10, 0), color.NewColor(1, 1, 1))
+
+	direction, distance := light.DirectionFrom(tuple.NewPoint(0, 2, 0), 0)
+
+	assert.Equal(t, 1, light.Samples())
+	assert.True(t, tuple.NewVector(0, 1, 0).Equals(direction))
+	assert.Equal(t, 8.0, distance)
+}
+
+func TestPointLightIsNotAttenuatedByDefault(t *testing.T) {
+	intensity := color.NewColor(1, 1, 1)
+	light := NewPointLight(tuple.NewPoint(0, 0, 0), intensity)
+
+	assert.Equal(t, intensity, light.IntensityAt(tuple.NewPoint(100, 0, 0)))
+}
+
+func TestAttenuatedPointLightFadesWithDistance(t *testing.T) {
+	light := NewAttenuatedPointLight(tuple.NewPoint(0, 0, 0), color.NewColor(1, 1, 1), Attenuation{Constant: 1, Linear: 0.5, Quadratic: 0.25})
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected float64
+	}{
+		{tuple.NewPoint(0, 0, 0), 1},
+		{tuple.NewPoint(0, 2, 0), 1.0 / 3},
+		{tuple.NewPoint(4, 0, 0), 1.0 / 7},
+	}
+
+	for _, tc := range cases {
+		expected := color.NewColor(tc.expected, tc.expected, tc.expected)
+		assert.True(t, expected.Equals(light.IntensityAt(tc.point)), "%v", tc.point)
+	}
+}
+
+func TestSequenceWrapsAround(t *testing.T) {
+	s := NewSequence(0.1, 0.5, 1.0)
+
+	assert.Equal(t, []float64{0.1, 0.5, 1.0, 0.1}, []float64{s.Float64(), s.Float64(), s.Float64(), s.Float64()})
+}
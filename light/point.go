@@ -0,0 +1,44 @@
+package light
+
+import (
+	"goray/color"
+	"goray/tuple"
+)
+
+// Attenuation scales a light by 1 / (Constant + Linear*d + Quadratic*d^2)
+// at distance d
+type Attenuation struct {
+	Constant, Linear, Quadratic float64
+}
+
+type PointLight struct {
+	Position    *tuple.Tuple
+	Attenuation *Attenuation
+}
+
+func NewPointLight(position *tuple.Tuple, intensity *color.Color) *Light {
+	return NewLight(&PointLight{Position: position}, intensity)
+}
+
+func NewAttenuatedPointLight(position *tuple.Tuple, intensity *color.Color, attenuation Attenuation) *Light {
+	return NewLight(&PointLight{Position: position, Attenuation: &attenuation}, intensity)
+}
+
+func (pl *PointLight) samples() int {
+	return 1
+}
+
+func (pl *PointLight) directionFrom(point *tuple.Tuple, sample int, jitter Jitter) (*tuple.Tuple, float64) {
+	return directionTo(pl.Position, point)
+}
+
+func (pl *PointLight) falloff(point *tuple.Tuple) float64 {
+	if pl.Attenuation == nil {
+		return 1
+	}
+
+	d := pl.Position.Sub(point).Magnitude()
+	a := pl.Attenuation
+
+	return 1 / (a.Constant + a.Linear*d + a.Quadratic*d*d)
+}
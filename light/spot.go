@@ -0,0 +1,51 @@
+package light
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+// SpotLight shines a cone along Direction; points within InnerAngle of the
+// axis are fully lit and the light fades out towards OuterAngle, with
+// Falloff shaping the curve in between
+type SpotLight struct {
+	Position   *tuple.Tuple
+	Direction  *tuple.Tuple
+	InnerAngle float64
+	OuterAngle float64
+	Falloff    float64
+}
+
+func NewSpotLight(position, direction *tuple.Tuple, innerAngle, outerAngle, falloff float64, intensity *color.Color) *Light {
+	return NewLight(&SpotLight{
+		Position:   position,
+		Direction:  direction.Normalize(),
+		InnerAngle: innerAngle,
+		OuterAngle: outerAngle,
+		Falloff:    falloff,
+	}, intensity)
+}
+
+func (sl *SpotLight) samples() int {
+	return 1
+}
+
+func (sl *SpotLight) directionFrom(point *tuple.Tuple, sample int, jitter Jitter) (*tuple.Tuple, float64) {
+	return directionTo(sl.Position, point)
+}
+
+func (sl *SpotLight) falloff(point *tuple.Tuple) float64 {
+	toPoint, _ := directionTo(point, sl.Position)
+	cosAngle := toPoint.Dot(sl.Direction)
+
+	cosInner, cosOuter := math.Cos(sl.InnerAngle), math.Cos(sl.OuterAngle)
+	if cosAngle >= cosInner {
+		return 1
+	}
+	if cosAngle <= cosOuter {
+		return 0
+	}
+
+	return math.Pow((cosAngle-cosOuter)/(cosInner-cosOuter), sl.Falloff)
+}
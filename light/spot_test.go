@@ -0,0 +1,53 @@
+package light
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestSpotLightPointsTowardsItsPosition(t *testing.T) {
+	light := NewSpotLight(tuple.NewPoint(0, 10, 0), tuple.NewVector(0, -2, 0), math.Pi/8, math.Pi/4, 1, color.NewColor(1, 1, 1))
+
+	direction, distance := light.DirectionFrom(tuple.NewPoint(0, 0, 0), 0)
+
+	assert.True(t, tuple.NewVector(0, 1, 0).Equals(direction))
+	assert.Equal(t, 10.0, distance)
+	assert.True(t, tuple.NewVector(0, -1, 0).Equals(light.lightType.(*SpotLight).Direction))
+}
+
+func TestSpotLightConeFalloff(t *testing.T) {
+	light := NewSpotLight(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0), math.Pi/8, math.Pi/4, 1, color.NewColor(1, 1, 1))
+
+	cosInner, cosOuter := math.Cos(math.Pi/8), math.Cos(math.Pi/4)
+	mid := math.Acos((cosInner + cosOuter) / 2)
+
+	cases := []struct {
+		name     string
+		point    *tuple.Tuple
+		expected float64
+	}{
+		{"on axis", tuple.NewPoint(0, -5, 0), 1},
+		{"inside inner cone", tuple.NewPoint(math.Tan(math.Pi/10), 0, 0), 1},
+		{"between cones", tuple.NewPoint(math.Tan(mid), 0, 0), 0.5},
+		{"outside outer cone", tuple.NewPoint(math.Tan(math.Pi/3), 0, 0), 0},
+		{"behind the light", tuple.NewPoint(0, 2, 0), 0},
+	}
+
+	for _, tc := range cases {
+		expected := color.NewColor(tc.expected, tc.expected, tc.expected)
+		assert.True(t, expected.Equals(light.IntensityAt(tc.point)), tc.name)
+	}
+}
+
+func TestSpotLightFalloffShapesTransition(t *testing.T) {
+	light := NewSpotLight(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0), math.Pi/8, math.Pi/4, 2, color.NewColor(1, 1, 1))
+	cosInner, cosOuter := math.Cos(math.Pi/8), math.Cos(math.Pi/4)
+	mid := math.Acos((cosInner + cosOuter) / 2)
+
+	c := light.IntensityAt(tuple.NewPoint(math.Tan(mid), 0, 0))
+
+	assert.InDelta(t, 0.25, c.Red, 0.00001)
+}
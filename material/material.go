@@ -3,49 +3,70 @@ package material
 import (
 	"goray/color"
 	"goray/light"
+	"goray/pattern"
 	"goray/tuple"
 	"math"
 )
 
 type Material struct {
-	Color     *color.Color
-	Ambient   float64
-	Diffuse   float64
-	Specular  float64
-	Shininess float64
+	Color           *color.Color
+	Pattern         *pattern.Pattern
+	Ambient         float64
+	Diffuse         float64
+	Specular        float64
+	Shininess       float64
+	Reflective      float64
+	Transparency    float64
+	RefractiveIndex float64
 }
 
 func NewMaterial() *Material {
-	return &Material{Color: color.NewColor(1, 1, 1), Ambient: 0.1, Diffuse: 0.9, Specular: 0.9, Shininess: 200.0}
+	return &Material{Color: color.NewColor(1, 1, 1), Ambient: 0.1, Diffuse: 0.9, Specular: 0.9, Shininess: 200.0, Reflective: 0, Transparency: 0, RefractiveIndex: 1.0}
 }
 
-func (m *Material) Lighting(l *light.Light, point *tuple.Tuple, eyeV *tuple.Tuple, normalV *tuple.Tuple, inShadow bool) *color.Color {
-	effectiveColor := m.Color.Multiply(l.Intensity)
+// Lighting averages the diffuse and specular contributions over every sample
+// of the light and scales them by intensity, the fraction of the light that
+// reaches point
+func (m *Material) Lighting(object pattern.Object, l *light.Light, point *tuple.Tuple, eyeV *tuple.Tuple, normalV *tuple.Tuple, intensity float64) *color.Color {
+	return m.LightingSamples(object, l, l.SamplesFrom(point, nil), point, eyeV, normalV, intensity)
+}
 
-	lightV := l.Position.Sub(point).Normalize()
+// LightingSamples is Lighting over light samples picked by the caller, which
+// lets shadow tests and shading use the same points on a jittered light
+func (m *Material) LightingSamples(object pattern.Object, l *light.Light, samples []light.Sample, point *tuple.Tuple, eyeV *tuple.Tuple, normalV *tuple.Tuple, intensity float64) *color.Color {
+	surfaceColor := m.Color
+	if m.Pattern != nil {
+		surfaceColor = m.Pattern.ColorAtObject(object, point)
+	}
 
+	// ambient follows the light's colour at the point, so it fades with
+	// attenuation and vanishes outside a spot light's cone like the rest
+	lightColor := l.IntensityAt(point)
+	effectiveColor := surfaceColor.Multiply(lightColor)
 	ambient := effectiveColor.MultiplyScalar(m.Ambient)
 
-	lightDotNormal := lightV.Dot(normalV)
+	diffuse := color.NewColor(0, 0, 0)
+	specular := color.NewColor(0, 0, 0)
+	for _, sample := range samples {
+		lightV := sample.Direction
 
-	var diffuse *color.Color
-	var specular *color.Color
-	if lightDotNormal < 0 || inShadow {
-		diffuse = color.NewColor(0, 0, 0)
-		specular = color.NewColor(0, 0, 0)
-	} else {
-		diffuse = effectiveColor.MultiplyScalar(m.Diffuse).MultiplyScalar(lightDotNormal)
+		lightDotNormal := lightV.Dot(normalV)
+		if lightDotNormal < 0 {
+			continue
+		}
+
+		diffuse = diffuse.Add(effectiveColor.MultiplyScalar(m.Diffuse).MultiplyScalar(lightDotNormal))
 
 		reflectV := lightV.Negate().Reflect(normalV)
 		reflectDotEye := reflectV.Dot(eyeV)
 
-		if reflectDotEye <= 0 {
-			specular = color.NewColor(0, 0, 0)
-		} else {
+		if reflectDotEye > 0 {
 			factor := math.Pow(reflectDotEye, m.Shininess)
-			specular = l.Intensity.MultiplyScalar(m.Specular).MultiplyScalar(factor)
+			specular = specular.Add(lightColor.MultiplyScalar(m.Specular).MultiplyScalar(factor))
 		}
 	}
 
-	return ambient.Add(diffuse).Add(specular)
+	scale := intensity / float64(len(samples))
+
+	return ambient.Add(diffuse.MultiplyScalar(scale)).Add(specular.MultiplyScalar(scale))
 }
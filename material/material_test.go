@@ -4,11 +4,18 @@ import (
 	"github.com/stretchr/testify/assert"
 	"goray/color"
 	"goray/light"
+	"goray/pattern"
 	"goray/tuple"
 	"math"
 	"testing"
 )
 
+type testObject struct{}
+
+func (o testObject) WorldToObject(point *tuple.Tuple) *tuple.Tuple {
+	return point
+}
+
 func TestDefaultMaterial(t *testing.T) {
 	m := NewMaterial()
 
@@ -17,6 +24,9 @@ func TestDefaultMaterial(t *testing.T) {
 	assert.Equal(t, 0.9, m.Diffuse)
 	assert.Equal(t, 0.9, m.Specular)
 	assert.Equal(t, 200.0, m.Shininess)
+	assert.Equal(t, 0.0, m.Reflective)
+	assert.Equal(t, 0.0, m.Transparency)
+	assert.Equal(t, 1.0, m.RefractiveIndex)
 }
 
 func TestLightingWithEyeBetweenLightAndSurface(t *testing.T) {
@@ -27,7 +37,7 @@ func TestLightingWithEyeBetweenLightAndSurface(t *testing.T) {
 	normalV := tuple.NewVector(0, 0, -1)
 	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))
 
-	result := m.Lighting(l, position, eyeV, normalV, false)
+	result := m.Lighting(testObject{}, l, position, eyeV, normalV, 1.0)
 
 	assert.Equal(t, color.NewColor(1.9, 1.9, 1.9), result)
 }
@@ -40,7 +50,7 @@ func TestLightingWithEyeBetweenLightAndSurfaceWithEyeOffset45Degrees(t *testing.
 	normalV := tuple.NewVector(0, 0, -1)
 	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))
 
-	result := m.Lighting(l, position, eyeV, normalV, false)
+	result := m.Lighting(testObject{}, l, position, eyeV, normalV, 1.0)
 
 	assert.Equal(t, color.NewColor(1.0, 1.0, 1.0), result)
 }
@@ -53,7 +63,7 @@ func TestLightingWithEyeOppositeSurfaceWithLightOffset45Degrees(t *testing.T) {
 	normalV := tuple.NewVector(0, 0, -1)
 	l := light.NewPointLight(tuple.NewPoint(0, 10, -10), color.NewColor(1, 1, 1))
 
-	result := m.Lighting(l, position, eyeV, normalV, false)
+	result := m.Lighting(testObject{}, l, position, eyeV, normalV, 1.0)
 
 	assert.True(t, result.Equals(color.NewColor(0.7364, 0.7364, 0.7364)))
 }
@@ -66,7 +76,7 @@ func TestLightingWithEyeInPathOfReflectionVector(t *testing.T) {
 	normalV := tuple.NewVector(0, 0, -1)
 	l := light.NewPointLight(tuple.NewPoint(0, 10, -10), color.NewColor(1, 1, 1))
 
-	result := m.Lighting(l, position, eyeV, normalV, false)
+	result := m.Lighting(testObject{}, l, position, eyeV, normalV, 1.0)
 
 	assert.True(t, result.Equals(color.NewColor(1.6364, 1.6364, 1.6364)))
 }
@@ -79,7 +89,7 @@ func TestLightingWithLightBehindSurface(t *testing.T) {
 	normalV := tuple.NewVector(0, 0, -1)
 	l := light.NewPointLight(tuple.NewPoint(0, 0, 10), color.NewColor(1, 1, 1))
 
-	result := m.Lighting(l, position, eyeV, normalV, false)
+	result := m.Lighting(testObject{}, l, position, eyeV, normalV, 1.0)
 
 	assert.True(t, result.Equals(color.NewColor(0.1, 0.1, 0.1)))
 }
@@ -91,9 +101,116 @@ func TestLightingWithSurfaceInShadow(t *testing.T) {
 	eyeV := tuple.NewVector(0, 0, -1)
 	normalV := tuple.NewVector(0, 0, -1)
 	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))
-	inShadow := true
+	intensity := 0.0
 
-	result := m.Lighting(l, position, eyeV, normalV, inShadow)
+	result := m.Lighting(testObject{}, l, position, eyeV, normalV, intensity)
 
 	assert.Equal(t, color.NewColor(0.1, 0.1, 0.1), result)
 }
+
+func TestLightingWithPatternApplied(t *testing.T) {
+	m := NewMaterial()
+	m.Pattern = pattern.NewStripePattern(color.NewColor(1, 1, 1), color.NewColor(0, 0, 0))
+	m.Ambient = 1
+	m.Diffuse = 0
+	m.Specular = 0
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))
+
+	c1 := m.Lighting(testObject{}, l, tuple.NewPoint(0.9, 0, 0), eyeV, normalV, 1.0)
+	c2 := m.Lighting(testObject{}, l, tuple.NewPoint(1.1, 0, 0), eyeV, normalV, 1.0)
+
+	assert.True(t, color.NewColor(1, 1, 1).Equals(c1))
+	assert.True(t, color.NewColor(0, 0, 0).Equals(c2))
+}
+
+func TestLightingUsesLightIntensityToAttenuateColor(t *testing.T) {
+	m := NewMaterial()
+	m.Ambient = 0.1
+	m.Diffuse = 0.9
+	m.Specular = 0
+	m.Color = color.NewColor(1, 1, 1)
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))
+	point := tuple.NewPoint(0, 0, -1)
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+
+	cases := []struct {
+		intensity float64
+		expected  *color.Color
+	}{
+		{1.0, color.NewColor(1, 1, 1)},
+		{0.5, color.NewColor(0.55, 0.55, 0.55)},
+		{0.0, color.NewColor(0.1, 0.1, 0.1)},
+	}
+
+	for _, tc := range cases {
+		result := m.Lighting(testObject{}, l, point, eyeV, normalV, tc.intensity)
+
+		assert.True(t, tc.expected.Equals(result), "intensity %v", tc.intensity)
+	}
+}
+
+func TestLightingSamplesTheAreaLight(t *testing.T) {
+	corner := tuple.NewPoint(-0.5, -0.5, -5)
+	l := light.NewAreaLight(corner, tuple.NewVector(1, 0, 0), 2, tuple.NewVector(0, 1, 0), 2, color.NewColor(1, 1, 1))
+	m := NewMaterial()
+	m.Ambient = 0.1
+	m.Diffuse = 0.9
+	m.Specular = 0
+	m.Color = color.NewColor(1, 1, 1)
+	eye := tuple.NewPoint(0, 0, -5)
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected *color.Color
+	}{
+		{tuple.NewPoint(0, 0, -1), color.NewColor(0.9965, 0.9965, 0.9965)},
+		{tuple.NewPoint(0, 0.7071, -0.7071), color.NewColor(0.62318, 0.62318, 0.62318)},
+	}
+
+	for _, tc := range cases {
+		eyeV := eye.Sub(tc.point).Normalize()
+		normalV := tuple.NewVector(tc.point.X, tc.point.Y, tc.point.Z)
+
+		result := m.Lighting(testObject{}, l, tc.point, eyeV, normalV, 1.0)
+
+		assert.InDelta(t, tc.expected.Red, result.Red, 0.0001)
+		assert.InDelta(t, tc.expected.Green, result.Green, 0.0001)
+		assert.InDelta(t, tc.expected.Blue, result.Blue, 0.0001)
+	}
+}
+
+func TestLightingOutsideSpotLightConeIsDark(t *testing.T) {
+	m := NewMaterial()
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+	l := light.NewSpotLight(tuple.NewPoint(0, 0, -10), tuple.NewVector(0, 1, 0), math.Pi/8, math.Pi/4, 1, color.NewColor(1, 1, 1))
+
+	result := m.Lighting(testObject{}, l, tuple.NewPoint(0, 0, 0), eyeV, normalV, 1.0)
+
+	assert.True(t, color.NewColor(0, 0, 0).Equals(result))
+}
+
+func TestAmbientFadesWithAttenuation(t *testing.T) {
+	m := NewMaterial()
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+	l := light.NewAttenuatedPointLight(tuple.NewPoint(0, 0, -3), color.NewColor(1, 1, 1), light.Attenuation{Constant: 1, Quadratic: 1})
+
+	result := m.Lighting(testObject{}, l, tuple.NewPoint(0, 0, 0), eyeV, normalV, 0)
+
+	assert.True(t, color.NewColor(0.01, 0.01, 0.01).Equals(result))
+}
+
+func TestLightingWithDirectionalLight(t *testing.T) {
+	m := NewMaterial()
+	eyeV := tuple.NewVector(0, 0, -1)
+	normalV := tuple.NewVector(0, 0, -1)
+	l := light.NewDirectionalLight(tuple.NewVector(0, 0, 1), color.NewColor(1, 1, 1))
+
+	result := m.Lighting(testObject{}, l, tuple.NewPoint(5, 5, 0), eyeV, normalV, 1.0)
+
+	assert.True(t, color.NewColor(1.9, 1.9, 1.9).Equals(result))
+}
@@ -0,0 +1,215 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"goray/ray"
+	"goray/shape"
+	"goray/tuple"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Parser struct {
+	Vertices []*tuple.Tuple
+	Normals  []*tuple.Tuple
+
+	DefaultGroup []*shape.Shape
+	Groups       map[string][]*shape.Shape
+	GroupNames   []string
+
+	Ignored int
+
+	current string
+}
+
+type faceVertex struct {
+	vertex *tuple.Tuple
+	normal *tuple.Tuple
+}
+
+func Parse(r io.Reader) (*Parser, error) {
+	p := &Parser{Groups: map[string][]*shape.Shape{}}
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		if err := p.parseLine(scanner.Text()); err != nil {
+			return nil, fmt.Errorf("obj: line %d: %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("obj: %w", err)
+	}
+
+	return p, nil
+}
+
+// Objects returns every parsed triangle, default group first and then the
+// named groups in the order they were first declared
+func (p *Parser) Objects() []ray.Object {
+	var objects []ray.Object
+	for _, s := range p.DefaultGroup {
+		objects = append(objects, s)
+	}
+	for _, name := range p.GroupNames {
+		for _, s := range p.Groups[name] {
+			objects = append(objects, s)
+		}
+	}
+
+	return objects
+}
+
+// ToGroup builds a single group holding the default group's triangles and
+// one child group per named group, so the whole model can be transformed
+// as one unit
+func (p *Parser) ToGroup() *shape.Shape {
+	g := shape.NewGroup(p.DefaultGroup...)
+	for _, name := range p.GroupNames {
+		g.AddChild(shape.NewGroup(p.Groups[name]...))
+	}
+
+	return g
+}
+
+func (p *Parser) parseLine(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	switch fields[0] {
+	case "v":
+		v, err := parseTriple(fields[1:])
+		if err != nil {
+			return fmt.Errorf("invalid vertex: %w", err)
+		}
+		p.Vertices = append(p.Vertices, tuple.NewPoint(v[0], v[1], v[2]))
+	case "vn":
+		n, err := parseTriple(fields[1:])
+		if err != nil {
+			return fmt.Errorf("invalid vertex normal: %w", err)
+		}
+		p.Normals = append(p.Normals, tuple.NewVector(n[0], n[1], n[2]))
+	case "f":
+		return p.parseFace(fields[1:])
+	case "g":
+		if len(fields) < 2 {
+			p.current = ""
+			return nil
+		}
+		p.current = strings.Join(fields[1:], " ")
+		if _, ok := p.Groups[p.current]; !ok {
+			p.Groups[p.current] = nil
+			p.GroupNames = append(p.GroupNames, p.current)
+		}
+	default:
+		p.Ignored++
+	}
+
+	return nil
+}
+
+func (p *Parser) parseFace(fields []string) error {
+	if len(fields) < 3 {
+		p.Ignored++
+		return nil
+	}
+
+	vertices := make([]faceVertex, len(fields))
+	for i, field := range fields {
+		fv, err := p.parseFaceVertex(field)
+		if err != nil {
+			return err
+		}
+		vertices[i] = fv
+	}
+
+	for _, t := range fanTriangulation(vertices) {
+		p.add(t)
+	}
+
+	return nil
+}
+
+func (p *Parser) parseFaceVertex(field string) (faceVertex, error) {
+	parts := strings.Split(field, "/")
+
+	vertex, err := lookup(p.Vertices, parts[0], "vertex")
+	if err != nil {
+		return faceVertex{}, err
+	}
+
+	fv := faceVertex{vertex: vertex}
+	if len(parts) == 3 && parts[2] != "" {
+		fv.normal, err = lookup(p.Normals, parts[2], "normal")
+		if err != nil {
+			return faceVertex{}, err
+		}
+	}
+
+	return fv, nil
+}
+
+func (p *Parser) add(o *shape.Shape) {
+	if p.current == "" {
+		p.DefaultGroup = append(p.DefaultGroup, o)
+		return
+	}
+	p.Groups[p.current] = append(p.Groups[p.current], o)
+}
+
+func fanTriangulation(vertices []faceVertex) []*shape.Shape {
+	var triangles []*shape.Shape
+
+	for i := 1; i < len(vertices)-1; i++ {
+		a, b, c := vertices[0], vertices[i], vertices[i+1]
+
+		if a.normal != nil && b.normal != nil && c.normal != nil {
+			triangles = append(triangles, shape.NewSmoothTriangle(a.vertex, b.vertex, c.vertex, a.normal, b.normal, c.normal))
+		} else {
+			triangles = append(triangles, shape.NewTriangle(a.vertex, b.vertex, c.vertex))
+		}
+	}
+
+	return triangles
+}
+
+// lookup resolves a 1-based OBJ index, where negative values count back from
+// the most recently declared element
+func lookup(elements []*tuple.Tuple, field, kind string) (*tuple.Tuple, error) {
+	index, err := strconv.Atoi(field)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s index %q", kind, field)
+	}
+
+	if index < 0 {
+		index = len(elements) + index + 1
+	}
+	if index < 1 || index > len(elements) {
+		return nil, fmt.Errorf("%s index %s out of range", kind, field)
+	}
+
+	return elements[index-1], nil
+}
+
+func parseTriple(fields []string) ([3]float64, error) {
+	var values [3]float64
+	if len(fields) < 3 {
+		return values, fmt.Errorf("expected 3 values, got %d", len(fields))
+	}
+
+	for i := range values {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return values, err
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
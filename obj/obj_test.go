@@ -0,0 +1,216 @@
+package obj
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/shape"
+	"goray/tuple"
+	"strings"
+	"testing"
+)
+
+func TestIgnoringUnrecognizedLines(t *testing.T) {
+	gibberish := `There was a young lady named Bright
+who traveled much faster than light.
+She set out one day
+in a relative way,
+and came back the previous night.
+`
+
+	p, err := Parse(strings.NewReader(gibberish))
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, p.Ignored)
+}
+
+func TestVertexRecords(t *testing.T) {
+	file := `v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, p.Vertices, 4)
+	assert.True(t, tuple.NewPoint(-1, 1, 0).Equals(p.Vertices[0]))
+	assert.True(t, tuple.NewPoint(-1, 0.5, 0).Equals(p.Vertices[1]))
+	assert.True(t, tuple.NewPoint(1, 0, 0).Equals(p.Vertices[2]))
+	assert.True(t, tuple.NewPoint(1, 1, 0).Equals(p.Vertices[3]))
+}
+
+func TestParsingTriangleFaces(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+f 1 3 4
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, p.DefaultGroup, 2)
+	assert.Equal(t, shape.NewTriangle(p.Vertices[0], p.Vertices[1], p.Vertices[2]), p.DefaultGroup[0])
+	assert.Equal(t, shape.NewTriangle(p.Vertices[0], p.Vertices[2], p.Vertices[3]), p.DefaultGroup[1])
+}
+
+func TestTriangulatingPolygons(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, p.DefaultGroup, 3)
+	for i, o := range p.DefaultGroup {
+		assert.Equal(t, shape.NewTriangle(p.Vertices[0], p.Vertices[i+1], p.Vertices[i+2]), o)
+	}
+}
+
+func TestTrianglesInGroups(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FirstGroup", "SecondGroup"}, p.GroupNames)
+	require.Len(t, p.Groups["FirstGroup"], 1)
+	require.Len(t, p.Groups["SecondGroup"], 1)
+	assert.Equal(t, shape.NewTriangle(p.Vertices[0], p.Vertices[1], p.Vertices[2]), p.Groups["FirstGroup"][0])
+	assert.Equal(t, shape.NewTriangle(p.Vertices[0], p.Vertices[2], p.Vertices[3]), p.Groups["SecondGroup"][0])
+	assert.Len(t, p.Objects(), 2)
+}
+
+func TestConvertingObjFileToGroup(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 4
+g FirstGroup
+f 1 2 3
+g SecondGroup
+f 1 3 4
+`
+
+	p, err := Parse(strings.NewReader(file))
+	require.NoError(t, err)
+
+	g := p.ToGroup()
+
+	children := g.Children()
+	require.Len(t, children, 3)
+	assert.Equal(t, p.DefaultGroup[0], children[0])
+	assert.Equal(t, p.Groups["FirstGroup"], children[1].Children())
+	assert.Equal(t, p.Groups["SecondGroup"], children[2].Children())
+	assert.Equal(t, g, children[1].GetParent())
+}
+
+func TestVertexNormalRecords(t *testing.T) {
+	file := `vn 0 0 1
+vn 0.707 0 -0.707
+vn 1 2 3
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, p.Normals, 3)
+	assert.True(t, tuple.NewVector(0, 0, 1).Equals(p.Normals[0]))
+	assert.True(t, tuple.NewVector(0.707, 0, -0.707).Equals(p.Normals[1]))
+	assert.True(t, tuple.NewVector(1, 2, 3).Equals(p.Normals[2]))
+}
+
+func TestFacesWithNormals(t *testing.T) {
+	file := `v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+f 1//3 2//1 3//2
+f 1/0/3 2/102/1 3/14/2
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, p.DefaultGroup, 2)
+	expected := shape.NewSmoothTriangle(p.Vertices[0], p.Vertices[1], p.Vertices[2], p.Normals[2], p.Normals[0], p.Normals[1])
+	for _, o := range p.DefaultGroup {
+		assert.Equal(t, expected, o)
+	}
+}
+
+func TestNegativeIndicesAreRelative(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+f -3 -2 -1
+`
+
+	p, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, p.DefaultGroup, 1)
+	assert.Equal(t, shape.NewTriangle(p.Vertices[0], p.Vertices[1], p.Vertices[2]), p.DefaultGroup[0])
+}
+
+func TestFaceWithOutOfRangeIndexReportsLine(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+f 1 2 3
+`
+
+	_, err := Parse(strings.NewReader(file))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+}
+
+func TestMalformedVertexBeforeFaceReportsLine(t *testing.T) {
+	file := `v -1 1 0
+v -1 zero 0
+v 1 0 0
+v 1 1 0
+f 1 2 3
+`
+
+	_, err := Parse(strings.NewReader(file))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestMalformedNormalReportsLine(t *testing.T) {
+	file := `vn 0 0 1
+vn 0.707 0
+`
+
+	_, err := Parse(strings.NewReader(file))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
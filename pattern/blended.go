@@ -0,0 +1,18 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+)
+
+type Blended struct {
+	a, b *Pattern
+}
+
+func NewBlendedPattern(a, b *Pattern) *Pattern {
+	return NewPattern(Blended{a: a, b: b})
+}
+
+func (bl Blended) colorAt(point *tuple.Tuple) *color.Color {
+	return bl.a.ColorAt(point).Add(bl.b.ColorAt(point)).MultiplyScalar(0.5)
+}
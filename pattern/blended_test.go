@@ -0,0 +1,21 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/transformation"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestBlendedPatternAveragesBothPatterns(t *testing.T) {
+	a := NewStripePattern(white, black)
+	b := NewStripePattern(white, black)
+	b.SetTransformation(transformation.NewRotationY(math.Pi / 2))
+	p := NewBlendedPattern(a, b)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0.5, 0, -0.5))))
+	assert.True(t, color.NewColor(0.5, 0.5, 0.5).Equals(p.ColorAt(tuple.NewPoint(1.5, 0, -0.5))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(1.5, 0, 0.5))))
+}
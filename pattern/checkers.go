@@ -0,0 +1,31 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"goray/utils"
+	"math"
+)
+
+type Checkers struct {
+	a, b *Pattern
+}
+
+func NewCheckersPattern(a, b *color.Color) *Pattern {
+	return NewNestedCheckersPattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+func NewNestedCheckersPattern(a, b *Pattern) *Pattern {
+	return NewPattern(Checkers{a: a, b: b})
+}
+
+func (c Checkers) colorAt(point *tuple.Tuple) *color.Color {
+	// nudging by EPSILON keeps faces lying exactly on a cell boundary (such as
+	// a plane at y=0) from flickering between the two colors
+	sum := math.Floor(point.X+utils.EPSILON) + math.Floor(point.Y+utils.EPSILON) + math.Floor(point.Z+utils.EPSILON)
+
+	if int(sum)%2 == 0 {
+		return c.a.ColorAt(point)
+	}
+	return c.b.ColorAt(point)
+}
@@ -0,0 +1,38 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/tuple"
+	"testing"
+)
+
+func TestCheckersShouldRepeatInX(t *testing.T) {
+	p := NewCheckersPattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0.99, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(1.01, 0, 0))))
+}
+
+func TestCheckersShouldRepeatInY(t *testing.T) {
+	p := NewCheckersPattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0.99, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(0, 1.01, 0))))
+}
+
+func TestCheckersShouldRepeatInZ(t *testing.T) {
+	p := NewCheckersPattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0.99))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(0, 0, 1.01))))
+}
+
+func TestCheckersAreStableOnCellBoundary(t *testing.T) {
+	p := NewCheckersPattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0.5, -0.000000001, 0.5))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0.5, 0.000000001, 0.5))))
+}
@@ -0,0 +1,68 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+type CubeFace int
+
+const (
+	CubeLeft CubeFace = iota
+	CubeFront
+	CubeRight
+	CubeBack
+	CubeUp
+	CubeDown
+)
+
+type CubeMap struct {
+	faces [6]UVPattern
+}
+
+func NewCubeMapPattern(left, front, right, back, up, down UVPattern) *Pattern {
+	return NewPattern(CubeMap{faces: [6]UVPattern{left, front, right, back, up, down}})
+}
+
+func (cm CubeMap) colorAt(point *tuple.Tuple) *color.Color {
+	face := FaceFromPoint(point)
+	u, v := cubeUV(face, point)
+
+	return cm.faces[face].UVColorAt(u, v)
+}
+
+func FaceFromPoint(point *tuple.Tuple) CubeFace {
+	absX, absY, absZ := math.Abs(point.X), math.Abs(point.Y), math.Abs(point.Z)
+	coord := math.Max(absX, math.Max(absY, absZ))
+
+	switch coord {
+	case point.X:
+		return CubeRight
+	case -point.X:
+		return CubeLeft
+	case point.Y:
+		return CubeUp
+	case -point.Y:
+		return CubeDown
+	case point.Z:
+		return CubeFront
+	}
+	return CubeBack
+}
+
+func cubeUV(face CubeFace, point *tuple.Tuple) (float64, float64) {
+	switch face {
+	case CubeFront:
+		return positiveMod(point.X+1, 2) / 2, positiveMod(point.Y+1, 2) / 2
+	case CubeBack:
+		return positiveMod(1-point.X, 2) / 2, positiveMod(point.Y+1, 2) / 2
+	case CubeLeft:
+		return positiveMod(point.Z+1, 2) / 2, positiveMod(point.Y+1, 2) / 2
+	case CubeRight:
+		return positiveMod(1-point.Z, 2) / 2, positiveMod(point.Y+1, 2) / 2
+	case CubeUp:
+		return positiveMod(point.X+1, 2) / 2, positiveMod(1-point.Z, 2) / 2
+	}
+	return positiveMod(point.X+1, 2) / 2, positiveMod(point.Z+1, 2) / 2
+}
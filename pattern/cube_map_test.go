@@ -0,0 +1,106 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/tuple"
+	"testing"
+)
+
+func TestIdentifyingFaceOfCubeFromPoint(t *testing.T) {
+	cases := []struct {
+		point    *tuple.Tuple
+		expected CubeFace
+	}{
+		{tuple.NewPoint(-1, 0.5, -0.25), CubeLeft},
+		{tuple.NewPoint(1.1, -0.75, 0.8), CubeRight},
+		{tuple.NewPoint(0.1, 0.6, 0.9), CubeFront},
+		{tuple.NewPoint(-0.7, 0, -2), CubeBack},
+		{tuple.NewPoint(0.5, 1, 0.9), CubeUp},
+		{tuple.NewPoint(-0.2, -1.3, 1.1), CubeDown},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, FaceFromPoint(tc.point), "%v", tc.point)
+	}
+}
+
+func TestUVMappingOfCubeFaces(t *testing.T) {
+	cases := []struct {
+		face  CubeFace
+		point *tuple.Tuple
+		u, v  float64
+	}{
+		{CubeFront, tuple.NewPoint(-0.5, 0.5, 1), 0.25, 0.75},
+		{CubeFront, tuple.NewPoint(0.5, -0.5, 1), 0.75, 0.25},
+		{CubeBack, tuple.NewPoint(0.5, 0.5, -1), 0.25, 0.75},
+		{CubeBack, tuple.NewPoint(-0.5, -0.5, -1), 0.75, 0.25},
+		{CubeLeft, tuple.NewPoint(-1, 0.5, -0.5), 0.25, 0.75},
+		{CubeLeft, tuple.NewPoint(-1, -0.5, 0.5), 0.75, 0.25},
+		{CubeRight, tuple.NewPoint(1, 0.5, 0.5), 0.25, 0.75},
+		{CubeRight, tuple.NewPoint(1, -0.5, -0.5), 0.75, 0.25},
+		{CubeUp, tuple.NewPoint(-0.5, 1, -0.5), 0.25, 0.75},
+		{CubeUp, tuple.NewPoint(0.5, 1, 0.5), 0.75, 0.25},
+		{CubeDown, tuple.NewPoint(-0.5, -1, 0.5), 0.25, 0.75},
+		{CubeDown, tuple.NewPoint(0.5, -1, -0.5), 0.75, 0.25},
+	}
+
+	for _, tc := range cases {
+		u, v := cubeUV(tc.face, tc.point)
+
+		assert.InDelta(t, tc.u, u, 0.00001, "u for %v", tc.point)
+		assert.InDelta(t, tc.v, v, 0.00001, "v for %v", tc.point)
+	}
+}
+
+func TestFindingColorsOnMappedCube(t *testing.T) {
+	white := color.NewColor(1, 1, 1)
+	p := NewCubeMapPattern(
+		NewUVAlignCheck(yellow, cyan, red, blue, brown),
+		NewUVAlignCheck(cyan, red, yellow, brown, green),
+		NewUVAlignCheck(red, yellow, purple, green, white),
+		NewUVAlignCheck(green, purple, cyan, white, blue),
+		NewUVAlignCheck(brown, cyan, purple, red, yellow),
+		NewUVAlignCheck(purple, brown, green, blue, white),
+	)
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected *color.Color
+	}{
+		{tuple.NewPoint(-1, 0, 0), yellow},
+		{tuple.NewPoint(-1, 0.9, -0.9), cyan},
+		{tuple.NewPoint(-1, 0.9, 0.9), red},
+		{tuple.NewPoint(-1, -0.9, -0.9), blue},
+		{tuple.NewPoint(-1, -0.9, 0.9), brown},
+		{tuple.NewPoint(0, 0, 1), cyan},
+		{tuple.NewPoint(-0.9, 0.9, 1), red},
+		{tuple.NewPoint(0.9, 0.9, 1), yellow},
+		{tuple.NewPoint(-0.9, -0.9, 1), brown},
+		{tuple.NewPoint(0.9, -0.9, 1), green},
+		{tuple.NewPoint(1, 0, 0), red},
+		{tuple.NewPoint(1, 0.9, 0.9), yellow},
+		{tuple.NewPoint(1, 0.9, -0.9), purple},
+		{tuple.NewPoint(1, -0.9, 0.9), green},
+		{tuple.NewPoint(1, -0.9, -0.9), white},
+		{tuple.NewPoint(0, 0, -1), green},
+		{tuple.NewPoint(0.9, 0.9, -1), purple},
+		{tuple.NewPoint(-0.9, 0.9, -1), cyan},
+		{tuple.NewPoint(0.9, -0.9, -1), white},
+		{tuple.NewPoint(-0.9, -0.9, -1), blue},
+		{tuple.NewPoint(0, 1, 0), brown},
+		{tuple.NewPoint(-0.9, 1, -0.9), cyan},
+		{tuple.NewPoint(0.9, 1, -0.9), purple},
+		{tuple.NewPoint(-0.9, 1, 0.9), red},
+		{tuple.NewPoint(0.9, 1, 0.9), yellow},
+		{tuple.NewPoint(0, -1, 0), purple},
+		{tuple.NewPoint(-0.9, -1, 0.9), brown},
+		{tuple.NewPoint(0.9, -1, 0.9), green},
+		{tuple.NewPoint(-0.9, -1, -0.9), blue},
+		{tuple.NewPoint(0.9, -1, -0.9), white},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(p.ColorAt(tc.point)), "%v", tc.point)
+	}
+}
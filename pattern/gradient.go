@@ -0,0 +1,27 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+type Gradient struct {
+	a, b *Pattern
+}
+
+func NewGradientPattern(a, b *color.Color) *Pattern {
+	return NewNestedGradientPattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+func NewNestedGradientPattern(a, b *Pattern) *Pattern {
+	return NewPattern(Gradient{a: a, b: b})
+}
+
+func (g Gradient) colorAt(point *tuple.Tuple) *color.Color {
+	a := g.a.ColorAt(point)
+	b := g.b.ColorAt(point)
+	fraction := point.X - math.Floor(point.X)
+
+	return a.Add(b.Sub(a).MultiplyScalar(fraction))
+}
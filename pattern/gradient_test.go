@@ -0,0 +1,17 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/tuple"
+	"testing"
+)
+
+func TestGradientLinearlyInterpolatesBetweenColors(t *testing.T) {
+	p := NewGradientPattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, color.NewColor(0.75, 0.75, 0.75).Equals(p.ColorAt(tuple.NewPoint(0.25, 0, 0))))
+	assert.True(t, color.NewColor(0.5, 0.5, 0.5).Equals(p.ColorAt(tuple.NewPoint(0.5, 0, 0))))
+	assert.True(t, color.NewColor(0.25, 0.25, 0.25).Equals(p.ColorAt(tuple.NewPoint(0.75, 0, 0))))
+}
@@ -0,0 +1,41 @@
+package pattern
+
+import (
+	"goray/tuple"
+	"math"
+)
+
+// UVMapping projects a point in pattern space onto a 2D texture, returning
+// (u, v) in the range [0, 1)
+type UVMapping func(point *tuple.Tuple) (float64, float64)
+
+func SphericalMap(point *tuple.Tuple) (float64, float64) {
+	theta := math.Atan2(point.X, point.Z)
+	radius := math.Sqrt(point.X*point.X + point.Y*point.Y + point.Z*point.Z)
+	phi := math.Acos(point.Y / radius)
+
+	rawU := theta / (2 * math.Pi)
+
+	// flip u so it increases counter-clockwise when viewed from above
+	return 1 - (rawU + 0.5), 1 - phi/math.Pi
+}
+
+func PlanarMap(point *tuple.Tuple) (float64, float64) {
+	return positiveMod(point.X, 1), positiveMod(point.Z, 1)
+}
+
+func CylindricalMap(point *tuple.Tuple) (float64, float64) {
+	theta := math.Atan2(point.X, point.Z)
+	rawU := theta / (2 * math.Pi)
+
+	return 1 - (rawU + 0.5), positiveMod(point.Y, 1)
+}
+
+func positiveMod(a, b float64) float64 {
+	m := math.Mod(a, b)
+	if m < 0 {
+		m += b
+	}
+
+	return m
+}
@@ -0,0 +1,62 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/tuple"
+	"goray/utils"
+	"math"
+	"testing"
+)
+
+type uvCase struct {
+	point *tuple.Tuple
+	u, v  float64
+}
+
+func assertMapping(t *testing.T, mapping UVMapping, cases []uvCase) {
+	for _, tc := range cases {
+		u, v := mapping(tc.point)
+
+		assert.InDelta(t, tc.u, u, utils.EPSILON, "u for %v", tc.point)
+		assert.InDelta(t, tc.v, v, utils.EPSILON, "v for %v", tc.point)
+	}
+}
+
+func TestSphericalMappingOn3DPoint(t *testing.T) {
+	assertMapping(t, SphericalMap, []uvCase{
+		{tuple.NewPoint(0, 0, -1), 0.0, 0.5},
+		{tuple.NewPoint(1, 0, 0), 0.25, 0.5},
+		{tuple.NewPoint(0, 0, 1), 0.5, 0.5},
+		{tuple.NewPoint(-1, 0, 0), 0.75, 0.5},
+		{tuple.NewPoint(0, 1, 0), 0.5, 1.0},
+		{tuple.NewPoint(0, -1, 0), 0.5, 0.0},
+		{tuple.NewPoint(math.Sqrt2/2, math.Sqrt2/2, 0), 0.25, 0.75},
+	})
+}
+
+func TestPlanarMappingOn3DPoint(t *testing.T) {
+	assertMapping(t, PlanarMap, []uvCase{
+		{tuple.NewPoint(0.25, 0, 0.5), 0.25, 0.5},
+		{tuple.NewPoint(0.25, 0, -0.25), 0.25, 0.75},
+		{tuple.NewPoint(0.25, 0.5, -0.25), 0.25, 0.75},
+		{tuple.NewPoint(1.25, 0, 0.5), 0.25, 0.5},
+		{tuple.NewPoint(0.25, 0, -1.75), 0.25, 0.25},
+		{tuple.NewPoint(1, 0, -1), 0.0, 0.0},
+		{tuple.NewPoint(0, 0, 0), 0.0, 0.0},
+	})
+}
+
+func TestCylindricalMappingOn3DPoint(t *testing.T) {
+	assertMapping(t, CylindricalMap, []uvCase{
+		{tuple.NewPoint(0, 0, -1), 0.0, 0.0},
+		{tuple.NewPoint(0, 0.5, -1), 0.0, 0.5},
+		{tuple.NewPoint(0, 1, -1), 0.0, 0.0},
+		{tuple.NewPoint(0.70711, 0.5, -0.70711), 0.125, 0.5},
+		{tuple.NewPoint(1, 0.5, 0), 0.25, 0.5},
+		{tuple.NewPoint(0.70711, 0.5, 0.70711), 0.375, 0.5},
+		{tuple.NewPoint(0, -0.25, 1), 0.5, 0.75},
+		{tuple.NewPoint(-0.70711, 0.5, 0.70711), 0.625, 0.5},
+		{tuple.NewPoint(-1, 1.25, 0), 0.75, 0.25},
+		{tuple.NewPoint(-0.70711, 0.5, -0.70711), 0.875, 0.5},
+	})
+}
@@ -0,0 +1,54 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/matrix"
+	"goray/tuple"
+)
+
+type Object interface {
+	WorldToObject(point *tuple.Tuple) *tuple.Tuple
+}
+
+type patternType interface {
+	colorAt(point *tuple.Tuple) *color.Color
+}
+
+type Pattern struct {
+	transformation *matrix.Matrix
+	inverse        *matrix.Matrix
+	patternType    patternType
+}
+
+func NewPattern(patternType patternType) *Pattern {
+	return &Pattern{
+		transformation: matrix.NewIdentityMatrix4x4(),
+		inverse:        matrix.NewIdentityMatrix4x4(),
+		patternType:    patternType,
+	}
+}
+
+func (p *Pattern) SetTransformation(m *matrix.Matrix) {
+	p.transformation = m
+	p.inverse = m.Invert()
+}
+
+func (p *Pattern) GetTransformation() *matrix.Matrix {
+	return p.transformation
+}
+
+func (p *Pattern) GetInverse() *matrix.Matrix {
+	return p.inverse
+}
+
+func (p *Pattern) ColorAt(point *tuple.Tuple) *color.Color {
+	patternPoint := p.inverse.MultiplyTuple(point)
+
+	return p.patternType.colorAt(patternPoint)
+}
+
+func (p *Pattern) ColorAtObject(object Object, worldPoint *tuple.Tuple) *color.Color {
+	objectPoint := object.WorldToObject(worldPoint)
+
+	return p.ColorAt(objectPoint)
+}
@@ -0,0 +1,79 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/matrix"
+	"goray/transformation"
+	"goray/tuple"
+	"testing"
+)
+
+type TestPattern struct{}
+
+func NewTestPattern() *Pattern {
+	return NewPattern(TestPattern{})
+}
+
+func (tp TestPattern) colorAt(point *tuple.Tuple) *color.Color {
+	return color.NewColor(point.X, point.Y, point.Z)
+}
+
+type testObject struct {
+	transformation *matrix.Matrix
+}
+
+func (o testObject) WorldToObject(point *tuple.Tuple) *tuple.Tuple {
+	return o.transformation.Invert().MultiplyTuple(point)
+}
+
+func TestDefaultPatternTransformation(t *testing.T) {
+	p := NewTestPattern()
+
+	assert.True(t, matrix.NewIdentityMatrix4x4().Equals(p.GetTransformation()))
+}
+
+func TestAssigningPatternTransformation(t *testing.T) {
+	p := NewTestPattern()
+
+	p.SetTransformation(transformation.NewTranslation(1, 2, 3))
+
+	assert.True(t, transformation.NewTranslation(1, 2, 3).Equals(p.GetTransformation()))
+	assert.True(t, transformation.NewTranslation(-1, -2, -3).Equals(p.GetInverse()))
+}
+
+func TestPatternWithObjectTransformation(t *testing.T) {
+	o := testObject{transformation: transformation.NewScaling(2, 2, 2)}
+	p := NewTestPattern()
+
+	c := p.ColorAtObject(o, tuple.NewPoint(2, 3, 4))
+
+	assert.True(t, color.NewColor(1, 1.5, 2).Equals(c))
+}
+
+func TestPatternWithPatternTransformation(t *testing.T) {
+	o := testObject{transformation: matrix.NewIdentityMatrix4x4()}
+	p := NewTestPattern()
+	p.SetTransformation(transformation.NewScaling(2, 2, 2))
+
+	c := p.ColorAtObject(o, tuple.NewPoint(2, 3, 4))
+
+	assert.True(t, color.NewColor(1, 1.5, 2).Equals(c))
+}
+
+func TestPatternWithBothObjectAndPatternTransformation(t *testing.T) {
+	o := testObject{transformation: transformation.NewScaling(2, 2, 2)}
+	p := NewTestPattern()
+	p.SetTransformation(transformation.NewTranslation(0.5, 1, 1.5))
+
+	c := p.ColorAtObject(o, tuple.NewPoint(2.5, 3, 3.5))
+
+	assert.True(t, color.NewColor(0.75, 0.5, 0.25).Equals(c))
+}
+
+func TestSolidPatternIsConstant(t *testing.T) {
+	p := NewSolidPattern(color.NewColor(0.2, 0.4, 0.6))
+
+	assert.True(t, color.NewColor(0.2, 0.4, 0.6).Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, color.NewColor(0.2, 0.4, 0.6).Equals(p.ColorAt(tuple.NewPoint(-3, 7, 1.5))))
+}
@@ -0,0 +1,28 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+type Ring struct {
+	a, b *Pattern
+}
+
+func NewRingPattern(a, b *color.Color) *Pattern {
+	return NewNestedRingPattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+func NewNestedRingPattern(a, b *Pattern) *Pattern {
+	return NewPattern(Ring{a: a, b: b})
+}
+
+func (r Ring) colorAt(point *tuple.Tuple) *color.Color {
+	distance := math.Sqrt(point.X*point.X + point.Z*point.Z)
+
+	if int(math.Floor(distance))%2 == 0 {
+		return r.a.ColorAt(point)
+	}
+	return r.b.ColorAt(point)
+}
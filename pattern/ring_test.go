@@ -0,0 +1,16 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/tuple"
+	"testing"
+)
+
+func TestRingShouldExtendInBothXAndZ(t *testing.T) {
+	p := NewRingPattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(1, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(0, 0, 1))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(0.708, 0, 0.708))))
+}
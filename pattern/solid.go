@@ -0,0 +1,18 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+)
+
+type Solid struct {
+	color *color.Color
+}
+
+func NewSolidPattern(c *color.Color) *Pattern {
+	return NewPattern(Solid{color: c})
+}
+
+func (s Solid) colorAt(point *tuple.Tuple) *color.Color {
+	return s.color
+}
@@ -0,0 +1,26 @@
+package pattern
+
+import (
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+type Stripe struct {
+	a, b *Pattern
+}
+
+func NewStripePattern(a, b *color.Color) *Pattern {
+	return NewNestedStripePattern(NewSolidPattern(a), NewSolidPattern(b))
+}
+
+func NewNestedStripePattern(a, b *Pattern) *Pattern {
+	return NewPattern(Stripe{a: a, b: b})
+}
+
+func (s Stripe) colorAt(point *tuple.Tuple) *color.Color {
+	if int(math.Floor(point.X))%2 == 0 {
+		return s.a.ColorAt(point)
+	}
+	return s.b.ColorAt(point)
+}
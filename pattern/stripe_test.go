@@ -0,0 +1,51 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/color"
+	"goray/transformation"
+	"goray/tuple"
+	"testing"
+)
+
+var (
+	white = color.NewColor(1, 1, 1)
+	black = color.NewColor(0, 0, 0)
+)
+
+func TestStripePatternIsConstantInY(t *testing.T) {
+	p := NewStripePattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 1, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 2, 0))))
+}
+
+func TestStripePatternIsConstantInZ(t *testing.T) {
+	p := NewStripePattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 1))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 2))))
+}
+
+func TestStripePatternAlternatesInX(t *testing.T) {
+	p := NewStripePattern(white, black)
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0.9, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(1, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(-0.1, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(-1, 0, 0))))
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(-1.1, 0, 0))))
+}
+
+func TestNestedStripePatternUsesChildPatterns(t *testing.T) {
+	inner := NewStripePattern(white, black)
+	inner.SetTransformation(transformation.NewScaling(0.5, 1, 1))
+	p := NewNestedStripePattern(inner, NewSolidPattern(color.NewColor(1, 0, 0)))
+
+	assert.True(t, white.Equals(p.ColorAt(tuple.NewPoint(0.25, 0, 0))))
+	assert.True(t, black.Equals(p.ColorAt(tuple.NewPoint(0.75, 0, 0))))
+	assert.True(t, color.NewColor(1, 0, 0).Equals(p.ColorAt(tuple.NewPoint(1.25, 0, 0))))
+}
@@ -0,0 +1,94 @@
+package pattern
+
+import (
+	"goray/canvas"
+	"goray/color"
+	"goray/tuple"
+	"math"
+)
+
+type UVPattern interface {
+	UVColorAt(u, v float64) *color.Color
+}
+
+type TextureMap struct {
+	uvPattern UVPattern
+	mapping   UVMapping
+}
+
+func NewTextureMapPattern(uvPattern UVPattern, mapping UVMapping) *Pattern {
+	return NewPattern(TextureMap{uvPattern: uvPattern, mapping: mapping})
+}
+
+func (tm TextureMap) colorAt(point *tuple.Tuple) *color.Color {
+	u, v := tm.mapping(point)
+
+	return tm.uvPattern.UVColorAt(u, v)
+}
+
+type UVCheckers struct {
+	Width, Height float64
+	A, B          *color.Color
+}
+
+func NewUVCheckers(width, height float64, a, b *color.Color) *UVCheckers {
+	return &UVCheckers{Width: width, Height: height, A: a, B: b}
+}
+
+func (c *UVCheckers) UVColorAt(u, v float64) *color.Color {
+	u2 := math.Floor(u * c.Width)
+	v2 := math.Floor(v * c.Height)
+
+	if int(u2+v2)%2 == 0 {
+		return c.A
+	}
+	return c.B
+}
+
+// UVAlignCheck paints a distinct color in each corner of the texture, which
+// makes it easy to see how a face is oriented
+type UVAlignCheck struct {
+	Main, UpperLeft, UpperRight, BottomLeft, BottomRight *color.Color
+}
+
+func NewUVAlignCheck(main, ul, ur, bl, br *color.Color) *UVAlignCheck {
+	return &UVAlignCheck{Main: main, UpperLeft: ul, UpperRight: ur, BottomLeft: bl, BottomRight: br}
+}
+
+func (ac *UVAlignCheck) UVColorAt(u, v float64) *color.Color {
+	if v > 0.8 {
+		if u < 0.2 {
+			return ac.UpperLeft
+		}
+		if u > 0.8 {
+			return ac.UpperRight
+		}
+	} else if v < 0.2 {
+		if u < 0.2 {
+			return ac.BottomLeft
+		}
+		if u > 0.8 {
+			return ac.BottomRight
+		}
+	}
+
+	return ac.Main
+}
+
+type UVImage struct {
+	Canvas *canvas.Canvas
+}
+
+func NewUVImage(c *canvas.Canvas) *UVImage {
+	return &UVImage{Canvas: c}
+}
+
+func (im *UVImage) UVColorAt(u, v float64) *color.Color {
+	// v grows upwards while canvas rows grow downwards
+	v = 1 - v
+
+	x := math.Round(u * float64(im.Canvas.Width-1))
+	y := math.Round(v * float64(im.Canvas.Height-1))
+
+	return im.Canvas.PixelAt(int(x), int(y))
+}
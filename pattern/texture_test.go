@@ -0,0 +1,108 @@
+package pattern
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/canvas"
+	"goray/color"
+	"goray/tuple"
+	"strings"
+	"testing"
+)
+
+var (
+	red    = color.NewColor(1, 0, 0)
+	yellow = color.NewColor(1, 1, 0)
+	brown  = color.NewColor(1, 0.5, 0)
+	green  = color.NewColor(0, 1, 0)
+	cyan   = color.NewColor(0, 1, 1)
+	blue   = color.NewColor(0, 0, 1)
+	purple = color.NewColor(1, 0, 1)
+)
+
+func TestCheckerPatternIn2D(t *testing.T) {
+	checkers := NewUVCheckers(2, 2, black, white)
+
+	assert.True(t, black.Equals(checkers.UVColorAt(0.0, 0.0)))
+	assert.True(t, white.Equals(checkers.UVColorAt(0.5, 0.0)))
+	assert.True(t, white.Equals(checkers.UVColorAt(0.0, 0.5)))
+	assert.True(t, black.Equals(checkers.UVColorAt(0.5, 0.5)))
+	assert.True(t, black.Equals(checkers.UVColorAt(1.0, 1.0)))
+}
+
+func TestTextureMapPatternWithSphericalMap(t *testing.T) {
+	p := NewTextureMapPattern(NewUVCheckers(16, 8, black, white), SphericalMap)
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected *color.Color
+	}{
+		{tuple.NewPoint(0.4315, 0.4670, 0.7719), white},
+		{tuple.NewPoint(-0.9654, 0.2552, -0.0534), black},
+		{tuple.NewPoint(0.1039, 0.7090, 0.6975), white},
+		{tuple.NewPoint(-0.4986, -0.7856, -0.3663), black},
+		{tuple.NewPoint(-0.0317, -0.9395, 0.3411), black},
+		{tuple.NewPoint(0.4809, -0.7721, 0.4154), black},
+		{tuple.NewPoint(0.0285, -0.9612, -0.2745), black},
+		{tuple.NewPoint(-0.5734, -0.2162, -0.7903), white},
+		{tuple.NewPoint(0.7688, -0.1470, 0.6223), black},
+		{tuple.NewPoint(-0.7652, 0.2175, 0.6060), black},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(p.ColorAt(tc.point)), "%v", tc.point)
+	}
+}
+
+func TestLayoutOfAlignCheckPattern(t *testing.T) {
+	p := NewUVAlignCheck(white, red, yellow, green, cyan)
+
+	cases := []struct {
+		u, v     float64
+		expected *color.Color
+	}{
+		{0.5, 0.5, white},
+		{0.1, 0.9, red},
+		{0.9, 0.9, yellow},
+		{0.1, 0.1, green},
+		{0.9, 0.1, cyan},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(p.UVColorAt(tc.u, tc.v)), "(%v, %v)", tc.u, tc.v)
+	}
+}
+
+func TestImageTextureReadsFromCanvas(t *testing.T) {
+	ppm := `P3
+10 10
+10
+0 0 0  1 1 1  2 2 2  3 3 3  4 4 4  5 5 5  6 6 6  7 7 7  8 8 8  9 9 9
+1 1 1  2 2 2  3 3 3  4 4 4  5 5 5  6 6 6  7 7 7  8 8 8  9 9 9  0 0 0
+2 2 2  3 3 3  4 4 4  5 5 5  6 6 6  7 7 7  8 8 8  9 9 9  0 0 0  1 1 1
+3 3 3  4 4 4  5 5 5  6 6 6  7 7 7  8 8 8  9 9 9  0 0 0  1 1 1  2 2 2
+4 4 4  5 5 5  6 6 6  7 7 7  8 8 8  9 9 9  0 0 0  1 1 1  2 2 2  3 3 3
+5 5 5  6 6 6  7 7 7  8 8 8  9 9 9  0 0 0  1 1 1  2 2 2  3 3 3  4 4 4
+6 6 6  7 7 7  8 8 8  9 9 9  0 0 0  1 1 1  2 2 2  3 3 3  4 4 4  5 5 5
+7 7 7  8 8 8  9 9 9  0 0 0  1 1 1  2 2 2  3 3 3  4 4 4  5 5 5  6 6 6
+8 8 8  9 9 9  0 0 0  1 1 1  2 2 2  3 3 3  4 4 4  5 5 5  6 6 6  7 7 7
+9 9 9  0 0 0  1 1 1  2 2 2  3 3 3  4 4 4  5 5 5  6 6 6  7 7 7  8 8 8
+`
+	c, err := canvas.ReadPPM(strings.NewReader(ppm))
+	require.NoError(t, err)
+	p := NewUVImage(c)
+
+	cases := []struct {
+		u, v     float64
+		expected *color.Color
+	}{
+		{0, 0, color.NewColor(0.9, 0.9, 0.9)},
+		{0.3, 0, color.NewColor(0.2, 0.2, 0.2)},
+		{0.6, 0.3, color.NewColor(0.1, 0.1, 0.1)},
+		{1, 1, color.NewColor(0.9, 0.9, 0.9)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.expected.Equals(p.UVColorAt(tc.u, tc.v)), "(%v, %v)", tc.u, tc.v)
+	}
+}
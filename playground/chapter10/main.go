@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"goray/camera"
+	"goray/color"
+	"goray/light"
+	"goray/material"
+	"goray/pattern"
+	"goray/ray"
+	"goray/shape"
+	tr "goray/transformation"
+	"goray/tuple"
+	"goray/world"
+	"math"
+)
+
+func main() {
+	w := world.NewWorld()
+	w.Objects = append(w.Objects, getFloor(), getMiddleSphere(), getRightSphere(), getLeftSphere())
+	w.AddLight(light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1)))
+
+	c := camera.NewCamera(600, 300, math.Pi/3)
+	c.SetTransformation(tr.ViewTransform(tuple.NewPoint(0, 1.5, -5), tuple.NewPoint(0, 1, 0), tuple.NewVector(0, 1, 0)))
+
+	im := c.Render(w)
+
+	fmt.Print(im.ToPPM())
+}
+
+func getFloor() ray.Object {
+	floor := shape.NewPlane()
+	mat := material.NewMaterial()
+	mat.Pattern = pattern.NewCheckersPattern(color.NewColor(1, 0.9, 0.9), color.NewColor(0.3, 0.2, 0.2))
+	mat.Specular = 0
+	floor.SetMaterial(mat)
+
+	return floor
+}
+
+func getMiddleSphere() ray.Object {
+	middle := shape.NewSphere()
+	middle.SetTransformation(tr.NewTranslation(-0.5, 1, 0.5))
+	mat := material.NewMaterial()
+	mat.Pattern = pattern.NewStripePattern(color.NewColor(0.1, 1, 0.5), color.NewColor(0.05, 0.5, 0.25))
+	mat.Pattern.SetTransformation(tr.NewRotationZ(math.Pi / 4).MultiplyMatrix(tr.NewScaling(0.2, 0.2, 0.2)))
+	mat.Diffuse = 0.7
+	mat.Specular = 0.3
+	middle.SetMaterial(mat)
+
+	return middle
+}
+
+func getRightSphere() ray.Object {
+	right := shape.NewSphere()
+	right.SetTransformation(tr.NewTranslation(1.5, 0.5, -0.5).MultiplyMatrix(tr.NewScaling(0.5, 0.5, 0.5)))
+	mat := material.NewMaterial()
+	mat.Pattern = pattern.NewGradientPattern(color.NewColor(0.5, 1, 0.1), color.NewColor(1, 0.2, 0.1))
+	mat.Pattern.SetTransformation(tr.NewTranslation(-1, 0, 0).MultiplyMatrix(tr.NewScaling(2, 1, 1)))
+	mat.Diffuse = 0.7
+	mat.Specular = 0.3
+	right.SetMaterial(mat)
+
+	return right
+}
+
+func getLeftSphere() ray.Object {
+	left := shape.NewSphere()
+	left.SetTransformation(tr.NewTranslation(-1.5, 0.33, -0.75).MultiplyMatrix(tr.NewScaling(0.33, 0.33, 0.33)))
+	mat := material.NewMaterial()
+	mat.Pattern = pattern.NewRingPattern(color.NewColor(1, 0.8, 0.1), color.NewColor(0.8, 0.4, 0.1))
+	mat.Pattern.SetTransformation(tr.NewScaling(0.2, 0.2, 0.2))
+	mat.Diffuse = 0.7
+	mat.Specular = 0.3
+	left.SetMaterial(mat)
+
+	return left
+}
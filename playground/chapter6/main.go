@@ -55,10 +55,10 @@ func main() {
 				hit := xs.Hit()
 
 				p := r.Position(hit.T)
-				normal := hit.Object.NormalAt(p)
+				normal := hit.Object.NormalAt(p, hit)
 				eye := r.Direction.Negate()
 
-				c := hit.Object.GetMaterial().Lighting(l, p, eye, normal, false)
+				c := hit.Object.GetMaterial().Lighting(hit.Object, l, p, eye, normal, 1.0)
 
 				canvs.WriteAt(x, y, c)
 			}
@@ -21,10 +21,10 @@ func main() {
 
 	w := world.NewWorld()
 	w.Objects = append(w.Objects, getFloor(bgMaterial), getMiddleSphere(), getRightSphere(), getLeftSphere())
-	w.Light = light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1))
+	w.AddLight(light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1)))
 
 	c := camera.NewCamera(600, 300, math.Pi/3)
-	c.Transform = tr.ViewTransform(tuple.NewPoint(0, 1.5, -5), tuple.NewPoint(0, 1, 0), tuple.NewVector(0, 1, 0))
+	c.SetTransformation(tr.ViewTransform(tuple.NewPoint(0, 1.5, -5), tuple.NewPoint(0, 1, 0), tuple.NewVector(0, 1, 0)))
 
 	im := c.Render(w)
 
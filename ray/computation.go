@@ -1,28 +1,38 @@
 package ray
 
 import (
+	"goray/light"
 	"goray/tuple"
 	"goray/utils"
+	"math"
 )
 
 type Computation struct {
-	T         float64
-	Object    Object
-	Point     *tuple.Tuple
-	EyeV      *tuple.Tuple
-	NormalV   *tuple.Tuple
-	Inside    bool
-	OverPoint *tuple.Tuple
+	T          float64
+	Object     Object
+	Point      *tuple.Tuple
+	EyeV       *tuple.Tuple
+	NormalV    *tuple.Tuple
+	ReflectV   *tuple.Tuple
+	Inside     bool
+	OverPoint  *tuple.Tuple
+	UnderPoint *tuple.Tuple
+	N1         float64
+	N2         float64
+	Time       float64
+	Jitter     light.Jitter
 }
 
-func (i *Intersection) PrepareComputations(r *Ray) *Computation {
+func (i *Intersection) PrepareComputations(r *Ray, xs *Intersections) *Computation {
 	c := &Computation{}
 
 	c.T = i.T
 	c.Object = i.Object
+	c.Time = r.Time
+	c.Jitter = r.Jitter
 	c.Point = r.Position(c.T)
 	c.EyeV = r.Direction.Negate()
-	c.NormalV = c.Object.NormalAt(c.Point)
+	c.NormalV = c.Object.NormalAt(c.Point, i)
 
 	if c.NormalV.Dot(c.EyeV) < 0 {
 		c.Inside = true
@@ -31,7 +41,72 @@ func (i *Intersection) PrepareComputations(r *Ray) *Computation {
 		c.Inside = false
 	}
 
+	c.ReflectV = r.Direction.Reflect(c.NormalV)
 	c.OverPoint = c.Point.Add(c.NormalV.Multiply(utils.EPSILON))
+	c.UnderPoint = c.Point.Sub(c.NormalV.Multiply(utils.EPSILON))
+	c.N1, c.N2 = i.refractiveIndices(xs)
 
 	return c
 }
+
+func (i *Intersection) refractiveIndices(xs *Intersections) (float64, float64) {
+	n1, n2 := 1.0, 1.0
+	if xs == nil {
+		return n1, n2
+	}
+
+	var containers []Object
+	for _, x := range xs.GetAll() {
+		if x == i {
+			n1 = lastRefractiveIndex(containers)
+		}
+
+		index := indexOf(containers, x.Object)
+		if index >= 0 {
+			containers = append(containers[:index], containers[index+1:]...)
+		} else {
+			containers = append(containers, x.Object)
+		}
+
+		if x == i {
+			n2 = lastRefractiveIndex(containers)
+			break
+		}
+	}
+
+	return n1, n2
+}
+
+func lastRefractiveIndex(containers []Object) float64 {
+	if len(containers) == 0 {
+		return 1.0
+	}
+	return containers[len(containers)-1].GetMaterial().RefractiveIndex
+}
+
+func indexOf(objects []Object, o Object) int {
+	for index, x := range objects {
+		if x == o {
+			return index
+		}
+	}
+	return -1
+}
+
+func (c *Computation) Schlick() float64 {
+	cos := c.EyeV.Dot(c.NormalV)
+
+	if c.N1 > c.N2 {
+		n := c.N1 / c.N2
+		sin2T := n * n * (1 - cos*cos)
+		if sin2T > 1 {
+			return 1.0
+		}
+
+		cos = math.Sqrt(1 - sin2T)
+	}
+
+	r0 := math.Pow((c.N1-c.N2)/(c.N1+c.N2), 2)
+
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
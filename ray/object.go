@@ -7,7 +7,9 @@ import (
 
 type Object interface {
 	Intersect(r *Ray) Intersections
-	NormalAt(point *tuple.Tuple) *tuple.Tuple
+	NormalAt(point *tuple.Tuple, hit *Intersection) *tuple.Tuple
+	WorldToObject(point *tuple.Tuple) *tuple.Tuple
+	WorldToObjectAt(point *tuple.Tuple, time float64) *tuple.Tuple
 
 	GetMaterial() *material.Material
 }
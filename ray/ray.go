@@ -1,14 +1,21 @@
 package ray
 
 import (
+	"goray/light"
 	"goray/matrix"
 	"goray/tuple"
 	"math"
 )
 
+// Ray carries the moment it was cast, within the camera's shutter interval,
+// so moving shapes can be intersected where they were at that time, and the
+// Jitter that places soft light samples when shading its hit; rays without
+// one sample the centre of each light cell
 type Ray struct {
 	Origin    *tuple.Tuple
 	Direction *tuple.Tuple
+	Time      float64
+	Jitter    light.Jitter
 }
 
 func NewRay(origin, dir *tuple.Tuple) *Ray {
@@ -20,18 +27,25 @@ func (r *Ray) Position(t float64) *tuple.Tuple {
 }
 
 func (r *Ray) Transform(m *matrix.Matrix) *Ray {
-	return &Ray{Origin: m.MultiplyTuple(r.Origin), Direction: m.MultiplyTuple(r.Direction)}
+	return &Ray{Origin: m.MultiplyTuple(r.Origin), Direction: m.MultiplyTuple(r.Direction), Time: r.Time, Jitter: r.Jitter}
 }
 
 type Intersection struct {
 	T      float64
 	Object Object
+	U      float64
+	V      float64
+	Time   float64
 }
 
 func NewIntersection(t float64, o Object) *Intersection {
 	return &Intersection{T: t, Object: o}
 }
 
+func NewIntersectionWithUV(t float64, o Object, u, v float64) *Intersection {
+	return &Intersection{T: t, Object: o, U: u, V: v}
+}
+
 type Intersections struct {
 	elements []*Intersection
 }
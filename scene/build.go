@@ -0,0 +1,931 @@
+package scene
+
+import (
+	"fmt"
+	"goray/camera"
+	"goray/canvas"
+	"goray/color"
+	"goray/light"
+	"goray/material"
+	"goray/matrix"
+	"goray/obj"
+	"goray/pattern"
+	"goray/shape"
+	tr "goray/transformation"
+	"goray/tuple"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+func (l *loader) addCamera(it *item) error {
+	width, err := l.integer(it, "width", it.fields["width"])
+	if err != nil {
+		return err
+	}
+	height, err := l.integer(it, "height", it.fields["height"])
+	if err != nil {
+		return err
+	}
+	projection, needsFOV, err := l.projection(it)
+	if err != nil {
+		return err
+	}
+	var fov float64
+	if raw, ok := it.fields["field-of-view"]; ok || needsFOV {
+		if fov, err = l.number(it, "field-of-view", raw); err != nil {
+			return err
+		}
+	}
+	from, err := l.triple(it, "from", it.fields["from"])
+	if err != nil {
+		return err
+	}
+	to, err := l.triple(it, "to", it.fields["to"])
+	if err != nil {
+		return err
+	}
+	up, err := l.triple(it, "up", it.fields["up"])
+	if err != nil {
+		return err
+	}
+
+	if width <= 0 || height <= 0 {
+		return l.errorf(it, "width", "camera size must be positive, got %dx%d", width, height)
+	}
+
+	c := camera.NewCamera(width, height, fov)
+	c.SetProjection(projection)
+	c.SetTransformation(tr.ViewTransform(
+		tuple.NewPoint(from[0], from[1], from[2]),
+		tuple.NewPoint(to[0], to[1], to[2]),
+		tuple.NewVector(up[0], up[1], up[2]),
+	))
+	if raw, ok := it.fields["samples"]; ok {
+		if c.Samples, err = l.integer(it, "samples", raw); err != nil {
+			return err
+		}
+		if c.Samples < 1 {
+			return l.errorf(it, "samples", "samples must be at least 1")
+		}
+	}
+	if raw, ok := it.fields["sampling"]; ok {
+		name, _ := raw.(string)
+		if c.SamplePattern, err = camera.ParseSamplePattern(name); err != nil {
+			return l.errorf(it, "sampling", "%v", err)
+		}
+	}
+	if raw, ok := it.fields["aperture"]; ok {
+		if c.Aperture, err = l.number(it, "aperture", raw); err != nil {
+			return err
+		}
+	}
+	if raw, ok := it.fields["focal-distance"]; ok {
+		if c.FocalDistance, err = l.number(it, "focal-distance", raw); err != nil {
+			return err
+		}
+		if c.FocalDistance <= 0 {
+			return l.errorf(it, "focal-distance", "focal distance must be positive")
+		}
+	}
+	if raw, ok := it.fields["blades"]; ok {
+		if c.Blades, err = l.integer(it, "blades", raw); err != nil {
+			return err
+		}
+	}
+	if raw, ok := it.fields["shutter"]; ok {
+		interval, ok := raw.([]interface{})
+		if !ok || len(interval) != 2 {
+			return l.errorf(it, "shutter", "expected [open, close]")
+		}
+		if c.ShutterOpen, err = l.number(it, "shutter", interval[0]); err != nil {
+			return err
+		}
+		if c.ShutterClose, err = l.number(it, "shutter", interval[1]); err != nil {
+			return err
+		}
+		if c.ShutterClose < c.ShutterOpen {
+			return l.errorf(it, "shutter", "shutter closes before it opens")
+		}
+	}
+
+	l.scene.Camera = c
+
+	return nil
+}
+
+// projection also reports whether the projection needs a field of view
+func (l *loader) projection(it *item) (camera.Projection, bool, error) {
+	kind := "perspective"
+	if raw, ok := it.fields["projection"]; ok {
+		if kind, ok = raw.(string); !ok {
+			return nil, false, l.errorf(it, "projection", "projection must be a string")
+		}
+	}
+
+	switch kind {
+	case "perspective":
+		return camera.Perspective{}, true, nil
+	case "fisheye":
+		return camera.Fisheye{}, true, nil
+	case "orthographic":
+		width, err := l.number(it, "view-width", it.fields["view-width"])
+		if err != nil {
+			return nil, false, err
+		}
+		o, err := camera.NewOrthographic(width)
+		if err != nil {
+			return nil, false, l.errorf(it, "view-width", "%v", err)
+		}
+		return o, false, nil
+	case "equirectangular":
+		return camera.Equirectangular{}, false, nil
+	case "cube":
+		return camera.CubePanorama{}, false, nil
+	default:
+		return nil, false, l.errorf(it, "projection", "unknown projection %q", kind)
+	}
+}
+
+func (l *loader) addLight(it *item) error {
+	intensity, err := l.triple(it, "intensity", it.fields["intensity"])
+	if err != nil {
+		return err
+	}
+	c := color.NewColor(intensity[0], intensity[1], intensity[2])
+
+	kind := "point"
+	if _, ok := it.fields["corner"]; ok {
+		kind = "area"
+	}
+	if raw, ok := it.fields["type"]; ok {
+		if kind, ok = raw.(string); !ok {
+			return l.errorf(it, "type", "light type must be a string")
+		}
+	}
+
+	var lt *light.Light
+	switch kind {
+	case "point":
+		lt, err = l.pointLight(it, c)
+	case "area":
+		lt, err = l.areaLight(it, c)
+	case "spot":
+		lt, err = l.spotLight(it, c)
+	case "directional":
+		lt, err = l.directionalLight(it, c)
+	default:
+		return l.errorf(it, "type", "unknown light type %q", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	l.scene.World.AddLight(lt)
+
+	return nil
+}
+
+func (l *loader) pointLight(it *item, intensity *color.Color) (*light.Light, error) {
+	at, err := l.point(it, "at", it.fields["at"])
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := it.fields["attenuation"]
+	if !ok {
+		return light.NewPointLight(at, intensity), nil
+	}
+
+	a, err := l.triple(it, "attenuation", raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return light.NewAttenuatedPointLight(at, intensity, light.Attenuation{Constant: a[0], Linear: a[1], Quadratic: a[2]}), nil
+}
+
+func (l *loader) spotLight(it *item, intensity *color.Color) (*light.Light, error) {
+	at, err := l.point(it, "at", it.fields["at"])
+	if err != nil {
+		return nil, err
+	}
+	direction, err := l.vector(it, "direction", it.fields["direction"])
+	if err != nil {
+		return nil, err
+	}
+	inner, err := l.number(it, "inner-angle", it.fields["inner-angle"])
+	if err != nil {
+		return nil, err
+	}
+	outer, err := l.number(it, "outer-angle", it.fields["outer-angle"])
+	if err != nil {
+		return nil, err
+	}
+	if inner > outer {
+		return nil, l.errorf(it, "inner-angle", "inner angle must not exceed the outer angle")
+	}
+
+	falloff := 1.0
+	if raw, ok := it.fields["falloff"]; ok {
+		if falloff, err = l.number(it, "falloff", raw); err != nil {
+			return nil, err
+		}
+	}
+
+	return light.NewSpotLight(at, direction, inner, outer, falloff, intensity), nil
+}
+
+func (l *loader) directionalLight(it *item, intensity *color.Color) (*light.Light, error) {
+	direction, err := l.vector(it, "direction", it.fields["direction"])
+	if err != nil {
+		return nil, err
+	}
+
+	return light.NewDirectionalLight(direction, intensity), nil
+}
+
+func (l *loader) areaLight(it *item, intensity *color.Color) (*light.Light, error) {
+	corner, err := l.point(it, "corner", it.fields["corner"])
+	if err != nil {
+		return nil, err
+	}
+
+	var vectors [2]*tuple.Tuple
+	for i, key := range []string{"uvec", "vvec"} {
+		if vectors[i], err = l.vector(it, key, it.fields[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	var steps [2]int
+	for i, key := range []string{"usteps", "vsteps"} {
+		n, err := l.integer(it, key, it.fields[key])
+		if err != nil {
+			return nil, err
+		}
+		if n < 1 {
+			return nil, l.errorf(it, key, "light needs at least one step, got %d", n)
+		}
+		steps[i] = n
+	}
+
+	jitter := true
+	if raw, ok := it.fields["jitter"]; ok {
+		if jitter, ok = raw.(bool); !ok {
+			return nil, l.errorf(it, "jitter", "expected true or false, got %v", raw)
+		}
+	}
+	if jitter {
+		return light.NewJitteredAreaLight(corner, vectors[0], steps[0], vectors[1], steps[1], intensity), nil
+	}
+
+	return light.NewAreaLight(corner, vectors[0], steps[0], vectors[1], steps[1], intensity), nil
+}
+
+var builtinShapes = map[string]bool{
+	"sphere": true, "plane": true, "cube": true, "cylinder": true, "cone": true,
+	"triangle": true, "group": true, "csg": true, "obj": true,
+}
+
+func (l *loader) shape(it *item, field string, fields map[string]interface{}) (*shape.Shape, error) {
+	kind, ok := fields["add"].(string)
+	if !ok {
+		return nil, l.errorf(it, join(field, "add"), "shape type must be a string")
+	}
+
+	// a defined shape is expanded in place, with the entry's own fields
+	// taking precedence over the definition's
+	if defined, ok := l.defines[kind].(map[string]interface{}); ok && !builtinShapes[kind] {
+		if l.expanding[kind] {
+			return nil, l.errorf(it, join(field, "add"), "definition %q refers to itself", kind)
+		}
+		l.expanding[kind] = true
+		defer delete(l.expanding, kind)
+
+		local := merge(fields, nil)
+		delete(local, "add")
+		return l.shape(it, field, merge(defined, local))
+	}
+
+	s, err := l.primitive(it, field, kind, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	start := matrix.NewIdentityMatrix4x4()
+	if raw, ok := fields["transform"]; ok {
+		if start, err = l.transform(it, join(field, "transform"), raw); err != nil {
+			return nil, err
+		}
+		s.SetTransformation(start)
+	}
+
+	if raw, ok := fields["end-transform"]; ok {
+		end, err := l.transform(it, join(field, "end-transform"), raw)
+		if err != nil {
+			return nil, err
+		}
+		s.SetMotion(start, end)
+	}
+
+	if raw, ok := fields["keyframes"]; ok {
+		frames, err := l.keyframes(it, join(field, "keyframes"), raw)
+		if err != nil {
+			return nil, err
+		}
+		s.SetKeyframes(frames...)
+	}
+
+	if raw, ok := fields["material"]; ok {
+		m, err := l.material(it, join(field, "material"), raw)
+		if err != nil {
+			return nil, err
+		}
+		s.SetMaterial(m)
+	}
+
+	return s, nil
+}
+
+func (l *loader) primitive(it *item, field, kind string, fields map[string]interface{}) (*shape.Shape, error) {
+	switch kind {
+	case "sphere":
+		return shape.NewSphere(), nil
+	case "plane":
+		return shape.NewPlane(), nil
+	case "cube":
+		return shape.NewCube(), nil
+	case "cylinder", "cone":
+		return l.truncated(it, field, kind, fields)
+	case "triangle":
+		return l.triangle(it, field, fields)
+	case "group":
+		return l.group(it, field, fields)
+	case "csg":
+		return l.csg(it, field, fields)
+	case "obj":
+		return l.obj(it, field, fields)
+	}
+
+	return nil, l.errorf(it, join(field, "add"), "unknown shape type %q", kind)
+}
+
+func (l *loader) truncated(it *item, field, kind string, fields map[string]interface{}) (*shape.Shape, error) {
+	min, max := math.Inf(-1), math.Inf(1)
+	var err error
+
+	if raw, ok := fields["min"]; ok {
+		if min, err = l.number(it, join(field, "min"), raw); err != nil {
+			return nil, err
+		}
+	}
+	if raw, ok := fields["max"]; ok {
+		if max, err = l.number(it, join(field, "max"), raw); err != nil {
+			return nil, err
+		}
+	}
+
+	closed := false
+	if raw, ok := fields["closed"]; ok {
+		if closed, ok = raw.(bool); !ok {
+			return nil, l.errorf(it, join(field, "closed"), "expected true or false, got %v", raw)
+		}
+	}
+
+	if kind == "cone" {
+		return shape.NewTruncatedCone(min, max, closed), nil
+	}
+	return shape.NewTruncatedCylinder(min, max, closed), nil
+}
+
+func (l *loader) triangle(it *item, field string, fields map[string]interface{}) (*shape.Shape, error) {
+	var points [3]*tuple.Tuple
+	for i, key := range []string{"p1", "p2", "p3"} {
+		p, err := l.triple(it, join(field, key), fields[key])
+		if err != nil {
+			return nil, err
+		}
+		points[i] = tuple.NewPoint(p[0], p[1], p[2])
+	}
+
+	return shape.NewTriangle(points[0], points[1], points[2]), nil
+}
+
+func (l *loader) group(it *item, field string, fields map[string]interface{}) (*shape.Shape, error) {
+	rawChildren, ok := fields["children"].([]interface{})
+	if !ok {
+		return nil, l.errorf(it, join(field, "children"), "group needs a list of children")
+	}
+
+	g := shape.NewGroup()
+	for i, raw := range rawChildren {
+		childField := fmt.Sprintf("%s[%d]", join(field, "children"), i)
+		childFields, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, l.errorf(it, childField, "expected a shape mapping")
+		}
+
+		child, err := l.shape(it, childField, childFields)
+		if err != nil {
+			return nil, err
+		}
+		g.AddChild(child)
+	}
+
+	return g, nil
+}
+
+func (l *loader) csg(it *item, field string, fields map[string]interface{}) (*shape.Shape, error) {
+	operations := map[string]shape.Operation{
+		"union":        shape.Union,
+		"intersection": shape.Intersection,
+		"difference":   shape.Difference,
+	}
+
+	name, _ := fields["operation"].(string)
+	op, ok := operations[name]
+	if !ok {
+		return nil, l.errorf(it, join(field, "operation"), "unknown CSG operation %v", fields["operation"])
+	}
+
+	var children [2]*shape.Shape
+	for i, key := range []string{"left", "right"} {
+		childFields, ok := fields[key].(map[string]interface{})
+		if !ok {
+			return nil, l.errorf(it, join(field, key), "expected a shape mapping")
+		}
+
+		child, err := l.shape(it, join(field, key), childFields)
+		if err != nil {
+			return nil, err
+		}
+		children[i] = child
+	}
+
+	return shape.NewCSG(op, children[0], children[1]), nil
+}
+
+func (l *loader) keyframes(it *item, field string, raw interface{}) ([]shape.Keyframe, error) {
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, l.errorf(it, field, "expected a list of keyframes")
+	}
+
+	frames := make([]shape.Keyframe, len(list))
+	for i, entry := range list {
+		entryField := fmt.Sprintf("%s[%d]", field, i)
+
+		fields, err := l.mapping(it, entryField, entry)
+		if err != nil {
+			return nil, err
+		}
+		if frames[i].Time, err = l.number(it, join(entryField, "time"), fields["time"]); err != nil {
+			return nil, err
+		}
+		if frames[i].Transformation, err = l.transform(it, join(entryField, "transform"), fields["transform"]); err != nil {
+			return nil, err
+		}
+	}
+
+	return frames, nil
+}
+
+func (l *loader) obj(it *item, field string, fields map[string]interface{}) (*shape.Shape, error) {
+	name, ok := fields["file"].(string)
+	if !ok {
+		return nil, l.errorf(it, join(field, "file"), "obj needs a file name")
+	}
+
+	f, err := os.Open(l.path(name))
+	if err != nil {
+		return nil, l.errorf(it, join(field, "file"), "%v", err)
+	}
+	defer f.Close()
+
+	p, err := obj.Parse(f)
+	if err != nil {
+		return nil, l.errorf(it, join(field, "file"), "%v", err)
+	}
+	if p.Ignored > 0 {
+		l.warnf(it, join(field, "file"), "ignored %d unrecognised lines in %s", p.Ignored, name)
+	}
+
+	g := p.ToGroup()
+	if raw, ok := fields["divide"]; ok {
+		threshold, err := l.integer(it, join(field, "divide"), raw)
+		if err != nil {
+			return nil, err
+		}
+		g.Divide(threshold)
+	}
+
+	return g, nil
+}
+
+func (l *loader) material(it *item, field string, raw interface{}) (*material.Material, error) {
+	fields, err := l.mapping(it, field, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	m := material.NewMaterial()
+	for key, value := range fields {
+		f := join(field, key)
+
+		switch key {
+		case "color":
+			c, err := l.color(it, f, value)
+			if err != nil {
+				return nil, err
+			}
+			m.Color = c
+		case "pattern":
+			p, err := l.pattern(it, f, value)
+			if err != nil {
+				return nil, err
+			}
+			m.Pattern = p
+		case "ambient", "diffuse", "specular", "shininess", "reflective", "transparency", "refractive-index":
+			n, err := l.number(it, f, value)
+			if err != nil {
+				return nil, err
+			}
+			*materialField(m, key) = n
+		default:
+			return nil, l.errorf(it, f, "unknown material property")
+		}
+	}
+
+	return m, nil
+}
+
+func materialField(m *material.Material, key string) *float64 {
+	switch key {
+	case "ambient":
+		return &m.Ambient
+	case "diffuse":
+		return &m.Diffuse
+	case "specular":
+		return &m.Specular
+	case "shininess":
+		return &m.Shininess
+	case "reflective":
+		return &m.Reflective
+	case "transparency":
+		return &m.Transparency
+	}
+	return &m.RefractiveIndex
+}
+
+func (l *loader) pattern(it *item, field string, raw interface{}) (*pattern.Pattern, error) {
+	fields, err := l.mapping(it, field, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, _ := fields["type"].(string)
+
+	var p *pattern.Pattern
+	if kind == "map" {
+		if p, err = l.textureMap(it, field, fields); err != nil {
+			return nil, err
+		}
+	} else if kind == "blended" {
+		children, ok := fields["patterns"].([]interface{})
+		if !ok || len(children) != 2 {
+			return nil, l.errorf(it, join(field, "patterns"), "blended pattern needs exactly two patterns")
+		}
+
+		var parts [2]*pattern.Pattern
+		for i := range parts {
+			if parts[i], err = l.pattern(it, fmt.Sprintf("%s[%d]", join(field, "patterns"), i), children[i]); err != nil {
+				return nil, err
+			}
+		}
+		p = pattern.NewBlendedPattern(parts[0], parts[1])
+	} else {
+		colors, ok := fields["colors"].([]interface{})
+		if !ok || len(colors) != 2 {
+			return nil, l.errorf(it, join(field, "colors"), "pattern needs exactly two colors")
+		}
+
+		var cs [2]*color.Color
+		for i := range cs {
+			if cs[i], err = l.color(it, fmt.Sprintf("%s[%d]", join(field, "colors"), i), colors[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		switch kind {
+		case "stripes":
+			p = pattern.NewStripePattern(cs[0], cs[1])
+		case "gradient":
+			p = pattern.NewGradientPattern(cs[0], cs[1])
+		case "rings":
+			p = pattern.NewRingPattern(cs[0], cs[1])
+		case "checkers":
+			p = pattern.NewCheckersPattern(cs[0], cs[1])
+		default:
+			return nil, l.errorf(it, join(field, "type"), "unknown pattern type %v", fields["type"])
+		}
+	}
+
+	if raw, ok := fields["transform"]; ok {
+		m, err := l.transform(it, join(field, "transform"), raw)
+		if err != nil {
+			return nil, err
+		}
+		p.SetTransformation(m)
+	}
+
+	return p, nil
+}
+
+func (l *loader) textureMap(it *item, field string, fields map[string]interface{}) (*pattern.Pattern, error) {
+	kind, _ := fields["mapping"].(string)
+
+	if kind == "cube" {
+		var faces [6]pattern.UVPattern
+		for i, name := range []string{"left", "front", "right", "back", "up", "down"} {
+			uv, err := l.uvPattern(it, join(field, name), fields[name])
+			if err != nil {
+				return nil, err
+			}
+			faces[i] = uv
+		}
+
+		return pattern.NewCubeMapPattern(faces[0], faces[1], faces[2], faces[3], faces[4], faces[5]), nil
+	}
+
+	var mapping pattern.UVMapping
+	switch kind {
+	case "spherical":
+		mapping = pattern.SphericalMap
+	case "planar":
+		mapping = pattern.PlanarMap
+	case "cylindrical":
+		mapping = pattern.CylindricalMap
+	default:
+		return nil, l.errorf(it, join(field, "mapping"), "unknown mapping %v", fields["mapping"])
+	}
+
+	uv, err := l.uvPattern(it, join(field, "uv-pattern"), fields["uv-pattern"])
+	if err != nil {
+		return nil, err
+	}
+
+	return pattern.NewTextureMapPattern(uv, mapping), nil
+}
+
+func (l *loader) uvPattern(it *item, field string, raw interface{}) (pattern.UVPattern, error) {
+	fields, err := l.mapping(it, field, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fields["type"] {
+	case "checkers":
+		width, err := l.number(it, join(field, "width"), fields["width"])
+		if err != nil {
+			return nil, err
+		}
+		height, err := l.number(it, join(field, "height"), fields["height"])
+		if err != nil {
+			return nil, err
+		}
+		colors, ok := fields["colors"].([]interface{})
+		if !ok || len(colors) != 2 {
+			return nil, l.errorf(it, join(field, "colors"), "pattern needs exactly two colors")
+		}
+
+		var cs [2]*color.Color
+		for i := range cs {
+			if cs[i], err = l.color(it, fmt.Sprintf("%s[%d]", join(field, "colors"), i), colors[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		return pattern.NewUVCheckers(width, height, cs[0], cs[1]), nil
+	case "align-check":
+		colors, err := l.mapping(it, join(field, "colors"), fields["colors"])
+		if err != nil {
+			return nil, err
+		}
+
+		var cs [5]*color.Color
+		for i, name := range []string{"main", "ul", "ur", "bl", "br"} {
+			if cs[i], err = l.color(it, join(join(field, "colors"), name), colors[name]); err != nil {
+				return nil, err
+			}
+		}
+
+		return pattern.NewUVAlignCheck(cs[0], cs[1], cs[2], cs[3], cs[4]), nil
+	case "image":
+		name, ok := fields["file"].(string)
+		if !ok {
+			return nil, l.errorf(it, join(field, "file"), "image needs a file name")
+		}
+
+		f, err := os.Open(l.path(name))
+		if err != nil {
+			return nil, l.errorf(it, join(field, "file"), "%v", err)
+		}
+		defer f.Close()
+
+		c, err := canvas.Decode(f)
+		if err != nil {
+			return nil, l.errorf(it, join(field, "file"), "%v", err)
+		}
+
+		return pattern.NewUVImage(c), nil
+	}
+
+	return nil, l.errorf(it, join(field, "type"), "unknown uv pattern type %v", fields["type"])
+}
+
+// transform composes a list of operations, each applied after the previous
+// one; entries may also name a defined list of operations
+func (l *loader) transform(it *item, field string, raw interface{}) (*matrix.Matrix, error) {
+	steps, ok := raw.([]interface{})
+	if !ok {
+		return nil, l.errorf(it, field, "expected a list of transformations")
+	}
+
+	m := matrix.NewIdentityMatrix4x4()
+	for i, step := range steps {
+		stepField := fmt.Sprintf("%s[%d]", field, i)
+
+		if name, ok := step.(string); ok {
+			defined, ok := l.defines[name]
+			if !ok {
+				return nil, l.errorf(it, stepField, "unknown transform definition %q", name)
+			}
+			if l.expanding[name] {
+				return nil, l.errorf(it, stepField, "definition %q refers to itself", name)
+			}
+			l.expanding[name] = true
+			sub, err := l.transform(it, stepField, defined)
+			delete(l.expanding, name)
+			if err != nil {
+				return nil, err
+			}
+			m = sub.MultiplyMatrix(m)
+			continue
+		}
+
+		op, err := l.operation(it, stepField, step)
+		if err != nil {
+			return nil, err
+		}
+		m = op.MultiplyMatrix(m)
+	}
+
+	return m, nil
+}
+
+func (l *loader) operation(it *item, field string, raw interface{}) (*matrix.Matrix, error) {
+	parts, ok := raw.([]interface{})
+	if !ok || len(parts) == 0 {
+		return nil, l.errorf(it, field, "expected [operation, arguments...]")
+	}
+
+	name, _ := parts[0].(string)
+	args := make([]float64, len(parts)-1)
+	for i, part := range parts[1:] {
+		n, err := l.number(it, field, part)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = n
+	}
+
+	expected := map[string]int{
+		"translate": 3, "scale": 3, "rotate-x": 1, "rotate-y": 1, "rotate-z": 1, "shear": 6,
+	}
+	count, ok := expected[name]
+	if !ok {
+		return nil, l.errorf(it, field, "unknown transformation %v", parts[0])
+	}
+	if count != len(args) {
+		return nil, l.errorf(it, field, "%s takes %d arguments, got %d", name, count, len(args))
+	}
+
+	switch name {
+	case "translate":
+		return tr.NewTranslation(args[0], args[1], args[2]), nil
+	case "scale":
+		return tr.NewScaling(args[0], args[1], args[2]), nil
+	case "rotate-x":
+		return tr.NewRotationX(args[0]), nil
+	case "rotate-y":
+		return tr.NewRotationY(args[0]), nil
+	case "rotate-z":
+		return tr.NewRotationZ(args[0]), nil
+	}
+	return tr.NewShearing(args[0], args[1], args[2], args[3], args[4], args[5]), nil
+}
+
+func (l *loader) mapping(it *item, field string, raw interface{}) (map[string]interface{}, error) {
+	if name, ok := raw.(string); ok {
+		defined, ok := l.defines[name]
+		if !ok {
+			return nil, l.errorf(it, field, "unknown definition %q", name)
+		}
+		raw = defined
+	}
+
+	fields, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, l.errorf(it, field, "expected a mapping or a defined name")
+	}
+
+	return fields, nil
+}
+
+func (l *loader) color(it *item, field string, raw interface{}) (*color.Color, error) {
+	c, err := l.triple(it, field, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return color.NewColor(c[0], c[1], c[2]), nil
+}
+
+func (l *loader) point(it *item, field string, raw interface{}) (*tuple.Tuple, error) {
+	p, err := l.triple(it, field, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return tuple.NewPoint(p[0], p[1], p[2]), nil
+}
+
+func (l *loader) vector(it *item, field string, raw interface{}) (*tuple.Tuple, error) {
+	v, err := l.triple(it, field, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return tuple.NewVector(v[0], v[1], v[2]), nil
+}
+
+func (l *loader) triple(it *item, field string, raw interface{}) ([3]float64, error) {
+	var values [3]float64
+
+	list, ok := raw.([]interface{})
+	if !ok || len(list) != 3 {
+		return values, l.errorf(it, field, "expected a list of 3 numbers")
+	}
+
+	for i := range values {
+		n, err := l.number(it, field, list[i])
+		if err != nil {
+			return values, err
+		}
+		values[i] = n
+	}
+
+	return values, nil
+}
+
+func (l *loader) number(it *item, field string, raw interface{}) (float64, error) {
+	switch n := raw.(type) {
+	case int:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case nil:
+		return 0, l.errorf(it, field, "missing number")
+	}
+
+	return 0, l.errorf(it, field, "expected a number, got %v", raw)
+}
+
+func (l *loader) integer(it *item, field string, raw interface{}) (int, error) {
+	n, ok := raw.(int)
+	if !ok {
+		if raw == nil {
+			return 0, l.errorf(it, field, "missing integer")
+		}
+		return 0, l.errorf(it, field, "expected an integer, got %v", raw)
+	}
+
+	return n, nil
+}
+
+// path resolves a file name relative to the directory of the scene file
+func (l *loader) path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+
+	return filepath.Join(l.dir, name)
+}
+
+func join(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
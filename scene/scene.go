@@ -0,0 +1,321 @@
+package scene
+
+import (
+	"fmt"
+	"goray/camera"
+	"goray/world"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Scene struct {
+	Camera *camera.Camera
+	World  *world.World
+	// Warnings lists problems that did not stop the scene from loading, such
+	// as OBJ lines that were skipped
+	Warnings []*Error
+}
+
+type Error struct {
+	Line  int
+	Field string
+	Err   error
+}
+
+func (e *Error) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("scene: line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("scene: line %d: %s: %v", e.Line, e.Field, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// item is a single top-level entry of a scene file together with its YAML
+// node, used to point errors at the offending field
+type item struct {
+	fields map[string]interface{}
+	node   *yaml.Node
+}
+
+type loader struct {
+	dir     string
+	defines map[string]interface{}
+	scene   *Scene
+
+	// defineNodes holds the YAML value of each definition and extends links a
+	// definition's value to the one it extends, so errors in expanded values
+	// can be traced back to where they were written
+	defineNodes map[string]*yaml.Node
+	extends     map[*yaml.Node]*yaml.Node
+
+	expanding map[string]bool
+}
+
+func Load(r io.Reader) (*Scene, error) {
+	return load(r, ".")
+}
+
+func LoadFile(path string) (*Scene, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("scene: %w", err)
+	}
+	defer f.Close()
+
+	return load(f, filepath.Dir(path))
+}
+
+func load(r io.Reader, dir string) (*Scene, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("scene: %w", err)
+	}
+
+	l := &loader{
+		dir:     dir,
+		defines: map[string]interface{}{},
+		scene:   &Scene{World: world.NewWorld()},
+
+		defineNodes: map[string]*yaml.Node{},
+		extends:     map[*yaml.Node]*yaml.Node{},
+		expanding:   map[string]bool{},
+	}
+
+	items, err := l.items(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, it := range items {
+		if err := l.process(it); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.scene.Camera == nil {
+		return nil, &Error{Line: 1, Err: fmt.Errorf("scene has no camera")}
+	}
+
+	return l.scene, nil
+}
+
+func (l *loader) items(doc *yaml.Node) ([]*item, error) {
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		return nil, &Error{Line: root.Line, Err: fmt.Errorf("expected a list of scene entries")}
+	}
+
+	items := make([]*item, len(root.Content))
+	for i, node := range root.Content {
+		var entry interface{}
+		if err := node.Decode(&entry); err != nil {
+			return nil, &Error{Line: node.Line, Err: err}
+		}
+
+		fields, ok := normalize(entry).(map[string]interface{})
+		if !ok {
+			return nil, &Error{Line: node.Line, Err: fmt.Errorf("expected a mapping, got %T", entry)}
+		}
+
+		items[i] = &item{fields: fields, node: node}
+	}
+
+	return items, nil
+}
+
+// lineOf finds the source line of a field path such as
+// "children[1].material.color" by walking the item's YAML nodes, following
+// defined names into their definitions. When the path leaves the document,
+// as it does for missing fields, the deepest line reached is used
+func (l *loader) lineOf(it *item, field string) int {
+	n := it.node
+	line := n.Line
+	for _, segment := range strings.Split(field, ".") {
+		key, indexes := segment, ""
+		if i := strings.IndexByte(segment, '['); i >= 0 {
+			key, indexes = segment[:i], segment[i:]
+		}
+
+		keyNode, value := l.lookup(n, key)
+		if value == nil {
+			return line
+		}
+		n, line = value, keyNode.Line
+
+		for _, part := range strings.Split(strings.Trim(indexes, "[]"), "][") {
+			index, err := strconv.Atoi(part)
+			if err != nil {
+				break
+			}
+
+			n = l.resolve(n)
+			if n.Kind != yaml.SequenceNode || index >= len(n.Content) {
+				return line
+			}
+			n = n.Content[index]
+			line = n.Line
+		}
+	}
+
+	return line
+}
+
+// lookup finds key in a mapping node, falling back to whatever the mapping
+// builds on: the defined shape it adds or the definition it extends
+func (l *loader) lookup(n *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for depth := 0; n != nil && depth <= len(l.defineNodes); depth++ {
+		n = l.resolve(n)
+		if n.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+
+		base := l.extends[n]
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if k.Value == key {
+				return k, v
+			}
+			if k.Value == "add" && !builtinShapes[v.Value] {
+				base = l.defineNodes[v.Value]
+			}
+		}
+		n = base
+	}
+
+	return nil, nil
+}
+
+// resolve follows aliases and defined names to the node they stand for
+func (l *loader) resolve(n *yaml.Node) *yaml.Node {
+	for depth := 0; depth <= len(l.defineNodes); depth++ {
+		switch {
+		case n.Kind == yaml.AliasNode:
+			n = n.Alias
+		case n.Kind == yaml.ScalarNode && l.defineNodes[n.Value] != nil:
+			n = l.defineNodes[n.Value]
+		default:
+			return n
+		}
+	}
+
+	return n
+}
+
+func (l *loader) errorf(it *item, field string, format string, args ...interface{}) error {
+	return &Error{Line: l.lineOf(it, field), Field: field, Err: fmt.Errorf(format, args...)}
+}
+
+func (l *loader) warnf(it *item, field string, format string, args ...interface{}) {
+	l.scene.Warnings = append(l.scene.Warnings, &Error{Line: l.lineOf(it, field), Field: field, Err: fmt.Errorf(format, args...)})
+}
+
+func (l *loader) process(it *item) error {
+	if name, ok := it.fields["define"]; ok {
+		return l.define(it, name)
+	}
+
+	kind, ok := it.fields["add"].(string)
+	if !ok {
+		return l.errorf(it, "add", "every entry needs an \"add\" or \"define\" key")
+	}
+
+	switch kind {
+	case "camera":
+		return l.addCamera(it)
+	case "light":
+		return l.addLight(it)
+	}
+
+	s, err := l.shape(it, "", it.fields)
+	if err != nil {
+		return err
+	}
+	l.scene.World.Objects = append(l.scene.World.Objects, s)
+
+	return nil
+}
+
+func (l *loader) define(it *item, rawName interface{}) error {
+	name, ok := rawName.(string)
+	if !ok {
+		return l.errorf(it, "define", "name must be a string")
+	}
+
+	value, ok := it.fields["value"]
+	if !ok {
+		return l.errorf(it, "value", "define %q has no value", name)
+	}
+
+	if base, ok := it.fields["extend"]; ok {
+		baseName, _ := base.(string)
+		parent, ok := l.defines[baseName].(map[string]interface{})
+		if !ok {
+			return l.errorf(it, "extend", "unknown mapping definition %v", base)
+		}
+
+		child, ok := value.(map[string]interface{})
+		if !ok {
+			return l.errorf(it, "value", "only mappings can extend a definition")
+		}
+
+		value = merge(parent, child)
+	}
+
+	l.defines[name] = value
+	_, node := l.lookup(it.node, "value")
+	l.defineNodes[name] = node
+	if base, ok := it.fields["extend"].(string); ok {
+		l.extends[node] = l.defineNodes[base]
+	}
+
+	return nil
+}
+
+func normalize(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[k] = normalize(v)
+		}
+		return m
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[fmt.Sprint(k)] = normalize(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, v := range t {
+			s[i] = normalize(v)
+		}
+		return s
+	}
+
+	return v
+}
+
+func merge(base, overrides map[string]interface{}) map[string]interface{} {
+	m := make(map[string]interface{}, len(base)+len(overrides))
+	for k, v := range base {
+		m[k] = v
+	}
+	for k, v := range overrides {
+		m[k] = v
+	}
+
+	return m
+}
@@ -0,0 +1,624 @@
+package scene
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/camera"
+	"goray/color"
+	"goray/light"
+	"goray/ray"
+	"goray/shape"
+	"goray/transformation"
+	"goray/tuple"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const cameraAndLight = `- add: camera
+  width: 100
+  height: 50
+  field-of-view: 0.785
+  from: [0, 1.5, -5]
+  to: [0, 1, 0]
+  up: [0, 1, 0]
+
+- add: light
+  at: [-10, 10, -10]
+  intensity: [1, 1, 1]
+`
+
+func TestLoadingCameraAndLight(t *testing.T) {
+	s, err := Load(strings.NewReader(cameraAndLight))
+
+	require.NoError(t, err)
+	assert.Equal(t, 100, s.Camera.HSize)
+	assert.Equal(t, 50, s.Camera.VSize)
+	assert.Equal(t, 0.785, s.Camera.FieldOfView)
+	assert.Equal(t, camera.StratifiedSampling, s.Camera.SamplePattern)
+	expected := transformation.ViewTransform(tuple.NewPoint(0, 1.5, -5), tuple.NewPoint(0, 1, 0), tuple.NewVector(0, 1, 0))
+	assert.True(t, expected.Equals(s.Camera.GetTransformation()))
+	assert.Equal(t, light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1)), s.World.Lights[0])
+}
+
+func TestLoadingThinLensCamera(t *testing.T) {
+	file := `- add: camera
+  width: 100
+  height: 50
+  field-of-view: 0.785
+  from: [0, 1.5, -5]
+  to: [0, 1, 0]
+  up: [0, 1, 0]
+  aperture: 0.05
+  focal-distance: 5.2
+  blades: 6
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.05, s.Camera.Aperture)
+	assert.Equal(t, 5.2, s.Camera.FocalDistance)
+	assert.Equal(t, 6, s.Camera.Blades)
+}
+
+func TestLoadingCameraProjections(t *testing.T) {
+	file := `- add: camera
+  width: 100
+  height: 50
+  projection: orthographic
+  view-width: 8
+  from: [0, 0, -5]
+  to: [0, 0, 0]
+  up: [0, 1, 0]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, camera.Orthographic{Width: 8}, s.Camera.GetProjection())
+	assert.Equal(t, 4.0, s.Camera.HalfWidth)
+	assert.Equal(t, 0.08, s.Camera.PixelSize)
+
+	for name, projection := range map[string]camera.Projection{
+		"equirectangular": camera.Equirectangular{},
+		"cube":            camera.CubePanorama{},
+	} {
+		file := strings.Replace(file, "orthographic\n  view-width: 8", name, 1)
+
+		s, err := Load(strings.NewReader(file))
+
+		require.NoError(t, err, name)
+		assert.Equal(t, projection, s.Camera.GetProjection(), name)
+	}
+
+	_, err = Load(strings.NewReader(strings.Replace(file, "view-width: 8", "view-width: 0", 1)))
+	assert.EqualError(t, err, "scene: line 5: view-width: orthographic view width must be positive, got 0")
+}
+
+func TestLoadingCameraSampling(t *testing.T) {
+	file := `- add: camera
+  width: 10
+  height: 10
+  field-of-view: 0.785
+  from: [0, 0, -5]
+  to: [0, 0, 0]
+  up: [0, 1, 0]
+  samples: 16
+  sampling: grid
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, 16, s.Camera.Samples)
+	assert.Equal(t, camera.GridSampling, s.Camera.SamplePattern)
+}
+
+func TestLoadingMovingShapes(t *testing.T) {
+	file := `- add: camera
+  width: 10
+  height: 10
+  field-of-view: 0.785
+  from: [0, 0, -5]
+  to: [0, 0, 0]
+  up: [0, 1, 0]
+  shutter: [0, 0.5]
+
+- add: sphere
+  transform:
+    - [translate, -1, 0, 0]
+  end-transform:
+    - [translate, 1, 0, 0]
+
+- add: cube
+  keyframes:
+    - time: 0
+      transform:
+        - [rotate-y, 0]
+    - time: 1
+      transform:
+        - [rotate-y, 1.5707963267948966]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, s.Camera.ShutterClose)
+	sphere := s.World.Objects[0].(*shape.Shape)
+	assert.True(t, sphere.IsMoving())
+	assert.True(t, transformation.NewTranslation(0, 0, 0).Equals(sphere.TransformationAt(0.5)))
+	cube := s.World.Objects[1].(*shape.Shape)
+	assert.Len(t, cube.GetKeyframes(), 2)
+	assert.True(t, transformation.NewRotationY(math.Pi/4).Equals(cube.TransformationAt(0.5)))
+}
+
+func TestLoadingSeveralLights(t *testing.T) {
+	file := cameraAndLight + `
+- add: light
+  at: [10, 10, -10]
+  intensity: [0.5, 0.5, 0.5]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, s.World.Lights, 2)
+	assert.Equal(t, light.NewPointLight(tuple.NewPoint(10, 10, -10), color.NewColor(0.5, 0.5, 0.5)), s.World.Lights[1])
+}
+
+func TestLoadingAreaLight(t *testing.T) {
+	file := `- add: camera
+  width: 10
+  height: 10
+  field-of-view: 0.785
+  from: [0, 0, -5]
+  to: [0, 0, 0]
+  up: [0, 1, 0]
+
+- add: light
+  corner: [-1, 2, 4]
+  uvec: [2, 0, 0]
+  vvec: [0, 2, 0]
+  usteps: 10
+  vsteps: 5
+  intensity: [1.5, 1.5, 1.5]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, s.World.Lights, 1)
+	expected := light.NewJitteredAreaLight(tuple.NewPoint(-1, 2, 4), tuple.NewVector(2, 0, 0), 10, tuple.NewVector(0, 2, 0), 5, color.NewColor(1.5, 1.5, 1.5))
+	assert.Equal(t, expected, s.World.Lights[0])
+}
+
+func TestLoadingSpotAndDirectionalLights(t *testing.T) {
+	file := cameraAndLight + `
+- add: light
+  type: spot
+  at: [0, 10, 0]
+  direction: [0, -1, 0]
+  inner-angle: 0.3
+  outer-angle: 0.5
+  falloff: 2
+  intensity: [1, 1, 1]
+
+- add: light
+  type: directional
+  direction: [1, -1, 0]
+  intensity: [0.2, 0.2, 0.2]
+
+- add: light
+  at: [0, 5, 0]
+  attenuation: [1, 0, 0.5]
+  intensity: [1, 1, 1]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, s.World.Lights, 4)
+	white := color.NewColor(1, 1, 1)
+	assert.Equal(t, light.NewSpotLight(tuple.NewPoint(0, 10, 0), tuple.NewVector(0, -1, 0), 0.3, 0.5, 2, white), s.World.Lights[1])
+	assert.Equal(t, light.NewDirectionalLight(tuple.NewVector(1, -1, 0), color.NewColor(0.2, 0.2, 0.2)), s.World.Lights[2])
+	assert.True(t, color.NewColor(1.0/3, 1.0/3, 1.0/3).Equals(s.World.Lights[3].IntensityAt(tuple.NewPoint(0, 3, 0))))
+}
+
+func TestLoadingShapesWithMaterialsAndTransforms(t *testing.T) {
+	file := cameraAndLight + `
+- add: sphere
+  material:
+    color: [1, 0.2, 1]
+    diffuse: 0.7
+    reflective: 0.3
+  transform:
+    - [scale, 2, 2, 2]
+    - [translate, 1, 0, 0]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, s.World.Objects, 1)
+	sphere := s.World.Objects[0].(*shape.Shape)
+	expectedSphere := shape.NewSphere()
+	expectedSphere.SetTransformation(sphere.GetTransformation())
+	expectedSphere.SetMaterial(sphere.GetMaterial())
+	assert.Equal(t, expectedSphere, sphere)
+	assert.Equal(t, color.NewColor(1, 0.2, 1), sphere.GetMaterial().Color)
+	assert.Equal(t, 0.7, sphere.GetMaterial().Diffuse)
+	assert.Equal(t, 0.3, sphere.GetMaterial().Reflective)
+	assert.Equal(t, 0.9, sphere.GetMaterial().Specular)
+	expected := transformation.NewTranslation(1, 0, 0).MultiplyMatrix(transformation.NewScaling(2, 2, 2))
+	assert.True(t, expected.Equals(sphere.GetTransformation()))
+}
+
+func TestDefinitionsCanBeReusedAndExtended(t *testing.T) {
+	file := cameraAndLight + `
+- define: white-material
+  value:
+    color: [1, 1, 1]
+    diffuse: 0.7
+    specular: 0.0
+
+- define: blue-material
+  extend: white-material
+  value:
+    color: [0.537, 0.831, 0.914]
+
+- define: standard-transform
+  value:
+    - [translate, 1, -1, 1]
+    - [scale, 0.5, 0.5, 0.5]
+
+- add: cube
+  material: blue-material
+  transform:
+    - standard-transform
+    - [scale, 3, 3, 3]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	cube := s.World.Objects[0].(*shape.Shape)
+	assert.Equal(t, color.NewColor(0.537, 0.831, 0.914), cube.GetMaterial().Color)
+	assert.Equal(t, 0.7, cube.GetMaterial().Diffuse)
+	assert.Equal(t, 0.0, cube.GetMaterial().Specular)
+	expected := transformation.NewScaling(3, 3, 3).
+		MultiplyMatrix(transformation.NewScaling(0.5, 0.5, 0.5)).
+		MultiplyMatrix(transformation.NewTranslation(1, -1, 1))
+	assert.True(t, expected.Equals(cube.GetTransformation()))
+}
+
+func TestDefinedShapesCanBeAdded(t *testing.T) {
+	file := cameraAndLight + `
+- define: pillar
+  value:
+    add: cylinder
+    min: 0
+    max: 3
+    closed: true
+
+- add: pillar
+  transform:
+    - [translate, 2, 0, 0]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	pillar := s.World.Objects[0].(*shape.Shape)
+	expected := shape.NewTruncatedCylinder(0, 3, true)
+	expected.SetTransformation(pillar.GetTransformation())
+	assert.Equal(t, expected, pillar)
+	assert.True(t, transformation.NewTranslation(2, 0, 0).Equals(pillar.GetTransformation()))
+}
+
+func TestLoadingGroupsCSGAndPatterns(t *testing.T) {
+	file := cameraAndLight + `
+- add: group
+  transform:
+    - [rotate-y, 1.5707963267948966]
+  children:
+    - add: csg
+      operation: difference
+      left:
+        add: cube
+      right:
+        add: cylinder
+        transform:
+          - [scale, 0.5, 1, 0.5]
+    - add: plane
+      material:
+        pattern:
+          type: checkers
+          colors:
+            - [1, 1, 1]
+            - [0, 0, 0]
+          transform:
+            - [scale, 0.5, 0.5, 0.5]
+`
+
+	s, err := Load(strings.NewReader(file))
+
+	require.NoError(t, err)
+	g := s.World.Objects[0].(*shape.Shape)
+	children := g.Children()
+	require.Len(t, children, 2)
+	// a difference keeps both walls of the cube and of the hole through it
+	xs := children[0].Intersect(ray.NewRay(tuple.NewPoint(-5, 0, 0), tuple.NewVector(1, 0, 0)))
+	require.Equal(t, 4, xs.Len())
+	assert.Equal(t, 4.0, xs.Get(0).T)
+	assert.Equal(t, 4.5, xs.Get(1).T)
+	assert.True(t, transformation.NewRotationY(math.Pi/2).Equals(g.GetTransformation()))
+	p := children[1].GetMaterial().Pattern
+	require.NotNil(t, p)
+	assert.True(t, color.NewColor(0, 0, 0).Equals(p.ColorAt(tuple.NewPoint(0.75, 0, 0))))
+}
+
+func TestLoadingObjFileRelativeToScene(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scene")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obj := "v -1 1 0\nv -1 0 0\nv 1 0 0\nf 1 2 3\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "model.obj"), []byte(obj), 0644))
+	file := cameraAndLight + `
+- add: obj
+  file: model.obj
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "scene.yml"), []byte(file), 0644))
+
+	s, err := LoadFile(filepath.Join(dir, "scene.yml"))
+
+	require.NoError(t, err)
+	g := s.World.Objects[0].(*shape.Shape)
+	assert.Len(t, g.Children(), 1)
+	assert.Empty(t, s.Warnings)
+}
+
+func TestIgnoredObjLinesAreReported(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scene")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obj := "mtllib model.mtl\nv -1 1 0\nv -1 0 0\nv 1 0 0\nusemtl red\nf 1 2 3\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "model.obj"), []byte(obj), 0644))
+	file := cameraAndLight + `
+- add: obj
+  file: model.obj
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "scene.yml"), []byte(file), 0644))
+
+	s, err := LoadFile(filepath.Join(dir, "scene.yml"))
+
+	require.NoError(t, err)
+	require.Len(t, s.Warnings, 1)
+	assert.EqualError(t, s.Warnings[0], "scene: line 14: file: ignored 2 unrecognised lines in model.obj")
+}
+
+func TestLoadingTextureMaps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scene")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "earth.ppm"), []byte("P3\n2 1\n255\n255 0 0 0 0 255\n"), 0644))
+	file := cameraAndLight + `
+- define: align
+  value:
+    type: align-check
+    colors:
+      main: [1, 1, 1]
+      ul: [1, 0, 0]
+      ur: [1, 1, 0]
+      bl: [0, 1, 0]
+      br: [0, 1, 1]
+
+- add: sphere
+  material:
+    pattern:
+      type: map
+      mapping: spherical
+      uv-pattern:
+        type: image
+        file: earth.ppm
+
+- add: cube
+  material:
+    pattern:
+      type: map
+      mapping: cube
+      left: align
+      front: align
+      right: align
+      back: align
+      up: align
+      down:
+        type: checkers
+        width: 2
+        height: 2
+        colors:
+          - [0, 0, 0]
+          - [1, 1, 1]
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "scene.yml"), []byte(file), 0644))
+
+	s, err := LoadFile(filepath.Join(dir, "scene.yml"))
+
+	require.NoError(t, err)
+	globe := s.World.Objects[0].(*shape.Shape).GetMaterial().Pattern
+	assert.True(t, color.NewColor(1, 0, 0).Equals(globe.ColorAt(tuple.NewPoint(0, 0, -1))))
+	assert.True(t, color.NewColor(0, 0, 1).Equals(globe.ColorAt(tuple.NewPoint(0, 0, 1))))
+	box := s.World.Objects[1].(*shape.Shape).GetMaterial().Pattern
+	assert.True(t, color.NewColor(1, 0, 0).Equals(box.ColorAt(tuple.NewPoint(-0.9, 0.9, 1))))
+	assert.True(t, color.NewColor(0, 0, 0).Equals(box.ColorAt(tuple.NewPoint(-0.9, -1, -0.9))))
+}
+
+func TestErrorsReportLineAndField(t *testing.T) {
+	cases := []struct {
+		name  string
+		file  string
+		line  int
+		field string
+	}{
+		{"unknown shape", cameraAndLight + `
+- add: teapot
+`, 13, "add"},
+		{"bad material value", cameraAndLight + `
+- add: sphere
+  material:
+    color: [1, 1, 1]
+    diffuse: shiny
+`, 16, "material.diffuse"},
+		{"bad transform", cameraAndLight + `
+- add: sphere
+  transform:
+    - [translate, 1, 2]
+`, 15, "transform[0]"},
+		{"nested child", cameraAndLight + `
+- add: group
+  children:
+    - add: sphere
+    - add: cone
+      closed: maybe
+`, 17, "children[1].closed"},
+		{"camera field", `- add: camera
+  width: 100
+  height: tall
+  field-of-view: 0.785
+  from: [0, 1.5, -5]
+  to: [0, 1, 0]
+  up: [0, 1, 0]
+`, 3, "height"},
+		{"unknown mapping", cameraAndLight + `
+- add: sphere
+  material:
+    pattern:
+      type: map
+      mapping: toroidal
+`, 17, "material.pattern.mapping"},
+		{"value from a definition", cameraAndLight + `
+- define: shiny
+  value:
+    color: [1, 1, 1]
+    diffuse: very
+
+- add: sphere
+  material: shiny
+`, 16, "material.diffuse"},
+		{"value from an extended definition", cameraAndLight + `
+- define: base
+  value:
+    reflective: lots
+
+- define: derived
+  extend: base
+  value:
+    color: [1, 1, 1]
+
+- add: sphere
+  material: derived
+`, 15, "material.reflective"},
+		{"defined shape", cameraAndLight + `
+- define: pillar
+  value:
+    add: cylinder
+    closed: maybe
+
+- add: pillar
+  transform:
+    - [translate, 2, 0, 0]
+`, 16, "closed"},
+		{"repeated key", cameraAndLight + `
+- add: sphere
+  material:
+    pattern:
+      type: stripes
+      colors:
+        - [1, 1, 1]
+        - [0, 0, 0]
+      transform:
+        - [scale, 1, 1, 1]
+  transform:
+    - [translate, 1, 2]
+`, 23, "transform[0]"},
+		{"unknown projection", `- add: camera
+  width: 100
+  height: 50
+  projection: cylindrical
+  from: [0, 1.5, -5]
+  to: [0, 1, 0]
+  up: [0, 1, 0]
+`, 4, "projection"},
+		{"transform defined in terms of itself", cameraAndLight + `
+- define: spin
+  value: [spin]
+
+- add: sphere
+  transform: [spin]
+`, 14, "transform[0][0]"},
+		{"transforms defined in terms of each other", cameraAndLight + `
+- define: spin
+  value:
+    - [rotate-y, 1]
+    - wobble
+
+- define: wobble
+  value: [[scale, 1, 2, 1], spin]
+
+- add: sphere
+  transform: [spin]
+`, 19, "transform[0][1][1]"},
+		{"unknown light", cameraAndLight + `
+- add: light
+  type: laser
+  intensity: [1, 1, 1]
+`, 14, "type"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tc.file))
+
+			require.Error(t, err)
+			var sceneErr *Error
+			require.True(t, errors.As(err, &sceneErr))
+			assert.Equal(t, tc.line, sceneErr.Line)
+			assert.Equal(t, tc.field, sceneErr.Field)
+			assert.Contains(t, err.Error(), tc.field)
+		})
+	}
+}
+
+func TestSyntaxErrorsIncludeLine(t *testing.T) {
+	_, err := Load(strings.NewReader("- add: sphere\n  material: [1, 2\n"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line")
+}
+
+func TestSceneWithoutCameraIsRejected(t *testing.T) {
+	_, err := Load(strings.NewReader("- add: sphere\n"))
+
+	assert.Error(t, err)
+}
+
+func TestSelfReferencingDefinitionIsRejected(t *testing.T) {
+	file := cameraAndLight + `
+- define: loop
+  value:
+    add: loop
+
+- add: loop
+`
+
+	_, err := Load(strings.NewReader(file))
+
+	assert.Error(t, err)
+}
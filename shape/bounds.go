@@ -0,0 +1,137 @@
+package shape
+
+import (
+	"goray/matrix"
+	"goray/ray"
+	"goray/tuple"
+	"math"
+)
+
+type Bounds struct {
+	Min *tuple.Tuple
+	Max *tuple.Tuple
+}
+
+func NewBounds(min, max *tuple.Tuple) *Bounds {
+	return &Bounds{Min: min, Max: max}
+}
+
+func NewEmptyBounds() *Bounds {
+	return NewBounds(
+		tuple.NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)),
+		tuple.NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+	)
+}
+
+func (b *Bounds) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+func (b *Bounds) AddPoint(p *tuple.Tuple) {
+	b.Min = tuple.NewPoint(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z))
+	b.Max = tuple.NewPoint(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z))
+}
+
+func (b *Bounds) Merge(other *Bounds) {
+	if other.IsEmpty() {
+		return
+	}
+
+	b.AddPoint(other.Min)
+	b.AddPoint(other.Max)
+}
+
+func (b *Bounds) ContainsPoint(p *tuple.Tuple) bool {
+	return b.Min.X <= p.X && p.X <= b.Max.X &&
+		b.Min.Y <= p.Y && p.Y <= b.Max.Y &&
+		b.Min.Z <= p.Z && p.Z <= b.Max.Z
+}
+
+func (b *Bounds) ContainsBounds(other *Bounds) bool {
+	return b.ContainsPoint(other.Min) && b.ContainsPoint(other.Max)
+}
+
+// Transform returns the axis-aligned box enclosing b after applying m. Each
+// output axis is accumulated term by term so that infinite extents (planes,
+// open cylinders) never produce 0 * Inf = NaN.
+func (b *Bounds) Transform(m *matrix.Matrix) *Bounds {
+	if b.IsEmpty() {
+		return NewEmptyBounds()
+	}
+
+	min := [3]float64{b.Min.X, b.Min.Y, b.Min.Z}
+	max := [3]float64{b.Max.X, b.Max.Y, b.Max.Z}
+	var newMin, newMax [3]float64
+
+	for row := 0; row < 3; row++ {
+		newMin[row] = m.At(row, 3)
+		newMax[row] = m.At(row, 3)
+
+		for col := 0; col < 3; col++ {
+			factor := m.At(row, col)
+			if factor == 0 {
+				continue
+			}
+
+			a := factor * min[col]
+			c := factor * max[col]
+			newMin[row] += math.Min(a, c)
+			newMax[row] += math.Max(a, c)
+		}
+	}
+
+	return NewBounds(
+		tuple.NewPoint(newMin[0], newMin[1], newMin[2]),
+		tuple.NewPoint(newMax[0], newMax[1], newMax[2]),
+	)
+}
+
+func (b *Bounds) Intersects(r *ray.Ray) bool {
+	xtMin, xtMax := checkAxis(r.Origin.X, r.Direction.X, b.Min.X, b.Max.X)
+	ytMin, ytMax := checkAxis(r.Origin.Y, r.Direction.Y, b.Min.Y, b.Max.Y)
+	ztMin, ztMax := checkAxis(r.Origin.Z, r.Direction.Z, b.Min.Z, b.Max.Z)
+
+	tMin := math.Max(xtMin, math.Max(ytMin, ztMin))
+	tMax := math.Min(xtMax, math.Min(ytMax, ztMax))
+
+	return tMin <= tMax
+}
+
+// Split halves the box along its longest finite axis
+func (b *Bounds) Split() (*Bounds, *Bounds) {
+	extents := [3]float64{b.Max.X - b.Min.X, b.Max.Y - b.Min.Y, b.Max.Z - b.Min.Z}
+
+	axis := -1
+	for i, extent := range extents {
+		if math.IsInf(extent, 0) || math.IsNaN(extent) {
+			continue
+		}
+		if axis == -1 || extent > extents[axis] {
+			axis = i
+		}
+	}
+
+	if axis == -1 {
+		return NewBounds(b.Min, b.Max), NewEmptyBounds()
+	}
+
+	x0, y0, z0 := b.Min.X, b.Min.Y, b.Min.Z
+	x1, y1, z1 := b.Max.X, b.Max.Y, b.Max.Z
+
+	switch axis {
+	case 0:
+		x0 = x0 + extents[0]/2
+		x1 = x0
+	case 1:
+		y0 = y0 + extents[1]/2
+		y1 = y0
+	default:
+		z0 = z0 + extents[2]/2
+		z1 = z0
+	}
+
+	left := NewBounds(b.Min, tuple.NewPoint(x1, y1, z1))
+	right := NewBounds(tuple.NewPoint(x0, y0, z0), b.Max)
+
+	return left, right
+}
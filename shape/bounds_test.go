@@ -0,0 +1,170 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/ray"
+	"goray/transformation"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestCreatingEmptyBounds(t *testing.T) {
+	b := NewEmptyBounds()
+
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, tuple.NewPoint(math.Inf(1), math.Inf(1), math.Inf(1)), b.Min)
+	assert.Equal(t, tuple.NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)), b.Max)
+}
+
+func TestAddingPointsToEmptyBounds(t *testing.T) {
+	b := NewEmptyBounds()
+
+	b.AddPoint(tuple.NewPoint(-5, 2, 0))
+	b.AddPoint(tuple.NewPoint(7, 0, -3))
+
+	assert.Equal(t, tuple.NewPoint(-5, 0, -3), b.Min)
+	assert.Equal(t, tuple.NewPoint(7, 2, 0), b.Max)
+}
+
+func TestBoundsOfPrimitives(t *testing.T) {
+	cases := []struct {
+		name     string
+		s        *Shape
+		min, max *tuple.Tuple
+	}{
+		{"sphere", NewSphere(), tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)},
+		{"plane", NewPlane(), tuple.NewPoint(math.Inf(-1), 0, math.Inf(-1)), tuple.NewPoint(math.Inf(1), 0, math.Inf(1))},
+		{"cube", NewCube(), tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)},
+		{"cylinder", NewCylinder(), tuple.NewPoint(-1, math.Inf(-1), -1), tuple.NewPoint(1, math.Inf(1), 1)},
+		{"truncated cylinder", NewTruncatedCylinder(-5, 3, false), tuple.NewPoint(-1, -5, -1), tuple.NewPoint(1, 3, 1)},
+		{"cone", NewCone(), tuple.NewPoint(math.Inf(-1), math.Inf(-1), math.Inf(-1)), tuple.NewPoint(math.Inf(1), math.Inf(1), math.Inf(1))},
+		{"truncated cone", NewTruncatedCone(-5, 3, false), tuple.NewPoint(-5, -5, -5), tuple.NewPoint(5, 3, 5)},
+		{"triangle", NewTriangle(tuple.NewPoint(-3, 7, 2), tuple.NewPoint(6, 2, -4), tuple.NewPoint(2, -1, -1)), tuple.NewPoint(-3, -1, -4), tuple.NewPoint(6, 7, 2)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := tc.s.Bounds()
+
+			assert.Equal(t, tc.min, b.Min)
+			assert.Equal(t, tc.max, b.Max)
+		})
+	}
+}
+
+func TestMergingBounds(t *testing.T) {
+	b1 := NewBounds(tuple.NewPoint(-5, -2, 0), tuple.NewPoint(7, 4, 4))
+	b2 := NewBounds(tuple.NewPoint(8, -7, -2), tuple.NewPoint(14, 2, 8))
+
+	b1.Merge(b2)
+
+	assert.Equal(t, tuple.NewPoint(-5, -7, -2), b1.Min)
+	assert.Equal(t, tuple.NewPoint(14, 4, 8), b1.Max)
+}
+
+func TestBoundsContainsPoint(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(5, -2, 0), tuple.NewPoint(11, 4, 7))
+
+	assert.True(t, b.ContainsPoint(tuple.NewPoint(5, -2, 0)))
+	assert.True(t, b.ContainsPoint(tuple.NewPoint(11, 4, 7)))
+	assert.True(t, b.ContainsPoint(tuple.NewPoint(8, 1, 3)))
+	assert.False(t, b.ContainsPoint(tuple.NewPoint(3, 0, 3)))
+	assert.False(t, b.ContainsPoint(tuple.NewPoint(8, -4, 3)))
+	assert.False(t, b.ContainsPoint(tuple.NewPoint(8, 1, -1)))
+	assert.False(t, b.ContainsPoint(tuple.NewPoint(13, 1, 3)))
+	assert.False(t, b.ContainsPoint(tuple.NewPoint(8, 5, 3)))
+	assert.False(t, b.ContainsPoint(tuple.NewPoint(8, 1, 8)))
+}
+
+func TestBoundsContainsBounds(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(5, -2, 0), tuple.NewPoint(11, 4, 7))
+
+	assert.True(t, b.ContainsBounds(NewBounds(tuple.NewPoint(5, -2, 0), tuple.NewPoint(11, 4, 7))))
+	assert.True(t, b.ContainsBounds(NewBounds(tuple.NewPoint(6, -1, 1), tuple.NewPoint(10, 3, 6))))
+	assert.False(t, b.ContainsBounds(NewBounds(tuple.NewPoint(4, -3, -1), tuple.NewPoint(10, 3, 6))))
+	assert.False(t, b.ContainsBounds(NewBounds(tuple.NewPoint(6, -1, 1), tuple.NewPoint(12, 5, 8))))
+}
+
+func TestTransformingBounds(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1))
+	m := transformation.NewRotationX(math.Pi / 4).MultiplyMatrix(transformation.NewRotationY(math.Pi / 4))
+
+	b2 := b.Transform(m)
+
+	assert.True(t, tuple.NewPoint(-1.41421, -1.70710, -1.70710).Equals(b2.Min))
+	assert.True(t, tuple.NewPoint(1.41421, 1.70710, 1.70710).Equals(b2.Max))
+}
+
+func TestTransformingInfiniteBoundsDoesNotProduceNaN(t *testing.T) {
+	b := NewPlane().Bounds()
+	m := transformation.NewTranslation(0, 2, 0).MultiplyMatrix(transformation.NewRotationZ(math.Pi / 2))
+
+	b2 := b.Transform(m)
+
+	assert.Equal(t, math.Inf(-1), b2.Min.X)
+	assert.Equal(t, math.Inf(1), b2.Max.X)
+	assert.Equal(t, math.Inf(-1), b2.Min.Y)
+	assert.Equal(t, math.Inf(1), b2.Max.Y)
+	assert.Equal(t, math.Inf(-1), b2.Min.Z)
+	assert.Equal(t, math.Inf(1), b2.Max.Z)
+}
+
+func TestParentSpaceBoundsOfShape(t *testing.T) {
+	s := NewSphere()
+	s.SetTransformation(transformation.NewTranslation(1, -3, 5).MultiplyMatrix(transformation.NewScaling(0.5, 2, 4)))
+
+	b := s.ParentSpaceBounds()
+
+	assert.True(t, tuple.NewPoint(0.5, -5, 1).Equals(b.Min))
+	assert.True(t, tuple.NewPoint(1.5, -1, 9).Equals(b.Max))
+}
+
+func TestIntersectingRayWithBounds(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(5, -2, 0), tuple.NewPoint(11, 4, 7))
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		result    bool
+	}{
+		{tuple.NewPoint(15, 1, 2), tuple.NewVector(-1, 0, 0), true},
+		{tuple.NewPoint(-5, -1, 4), tuple.NewVector(1, 0, 0), true},
+		{tuple.NewPoint(7, 6, 5), tuple.NewVector(0, -1, 0), true},
+		{tuple.NewPoint(9, -5, 6), tuple.NewVector(0, 1, 0), true},
+		{tuple.NewPoint(8, 2, 12), tuple.NewVector(0, 0, -1), true},
+		{tuple.NewPoint(6, 0, -5), tuple.NewVector(0, 0, 1), true},
+		{tuple.NewPoint(8, 1, 3.5), tuple.NewVector(0, 0, 1), true},
+		{tuple.NewPoint(9, -1, -8), tuple.NewVector(2, 4, 6), false},
+		{tuple.NewPoint(8, 3, -4), tuple.NewVector(6, 2, 4), false},
+		{tuple.NewPoint(9, -1, -2), tuple.NewVector(4, 6, 2), false},
+		{tuple.NewPoint(4, 0, 9), tuple.NewVector(0, 0, -1), false},
+		{tuple.NewPoint(8, 6, -1), tuple.NewVector(0, -1, 0), false},
+		{tuple.NewPoint(12, 5, 4), tuple.NewVector(-1, 0, 0), false},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		assert.Equal(t, tc.result, b.Intersects(r))
+	}
+}
+
+func TestSplittingBoundsAlongLongestAxis(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(-1, -2, -3), tuple.NewPoint(9, 5.5, 3))
+
+	left, right := b.Split()
+
+	assert.Equal(t, tuple.NewPoint(-1, -2, -3), left.Min)
+	assert.Equal(t, tuple.NewPoint(4, 5.5, 3), left.Max)
+	assert.Equal(t, tuple.NewPoint(4, -2, -3), right.Min)
+	assert.Equal(t, tuple.NewPoint(9, 5.5, 3), right.Max)
+}
+
+func TestSplittingBoundsIgnoresInfiniteAxes(t *testing.T) {
+	b := NewBounds(tuple.NewPoint(math.Inf(-1), -2, math.Inf(-1)), tuple.NewPoint(math.Inf(1), 2, math.Inf(1)))
+
+	left, right := b.Split()
+
+	assert.Equal(t, 0.0, left.Max.Y)
+	assert.Equal(t, 0.0, right.Min.Y)
+}
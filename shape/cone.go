@@ -0,0 +1,88 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+	"goray/utils"
+	"math"
+)
+
+type Cone struct {
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func NewCone() *Shape {
+	return NewShape(&Cone{Minimum: math.Inf(-1), Maximum: math.Inf(1), Closed: false})
+}
+
+func NewTruncatedCone(min, max float64, closed bool) *Shape {
+	return NewShape(&Cone{Minimum: min, Maximum: max, Closed: closed})
+}
+
+func (c *Cone) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	xs := ray.NewIntersections()
+
+	a := r.Direction.X*r.Direction.X - r.Direction.Y*r.Direction.Y + r.Direction.Z*r.Direction.Z
+	b := 2*r.Origin.X*r.Direction.X - 2*r.Origin.Y*r.Direction.Y + 2*r.Origin.Z*r.Direction.Z
+	cc := r.Origin.X*r.Origin.X - r.Origin.Y*r.Origin.Y + r.Origin.Z*r.Origin.Z
+
+	if math.Abs(a) < utils.EPSILON {
+		// the ray is parallel to one of the nappes and crosses the other once
+		if math.Abs(b) >= utils.EPSILON {
+			t := -cc / (2 * b)
+			c.addIfWithinBounds(r, s, xs, t)
+		}
+	} else {
+		discriminant := b*b - 4*a*cc
+		if discriminant < 0 {
+			return ray.Intersections{}
+		}
+
+		t0 := (-b - math.Sqrt(discriminant)) / (2 * a)
+		t1 := (-b + math.Sqrt(discriminant)) / (2 * a)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		c.addIfWithinBounds(r, s, xs, t0)
+		c.addIfWithinBounds(r, s, xs, t1)
+	}
+
+	if c.Closed {
+		intersectCaps(r, s, xs, c.Minimum, c.Maximum, math.Abs)
+	}
+
+	return *xs
+}
+
+func (c *Cone) addIfWithinBounds(r *ray.Ray, s *Shape, xs *ray.Intersections, t float64) {
+	y := r.Origin.Y + t*r.Direction.Y
+	if c.Minimum < y && y < c.Maximum {
+		xs.Add(ray.NewIntersection(t, s))
+	}
+}
+
+func (c *Cone) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	dist := point.X*point.X + point.Z*point.Z
+
+	if dist < point.Y*point.Y && point.Y >= c.Maximum-utils.EPSILON {
+		return tuple.NewVector(0, 1, 0)
+	} else if dist < point.Y*point.Y && point.Y <= c.Minimum+utils.EPSILON {
+		return tuple.NewVector(0, -1, 0)
+	}
+
+	y := math.Sqrt(dist)
+	if point.Y > 0 {
+		y = -y
+	}
+
+	return tuple.NewVector(point.X, y, point.Z)
+}
+
+func (c *Cone) bounds() *Bounds {
+	limit := math.Max(math.Abs(c.Minimum), math.Abs(c.Maximum))
+
+	return NewBounds(tuple.NewPoint(-limit, c.Minimum, -limit), tuple.NewPoint(limit, c.Maximum, limit))
+}
@@ -0,0 +1,96 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestIntersectingConeWithRay(t *testing.T) {
+	c := &Cone{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		t0, t1    float64
+	}{
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), 5, 5},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(1, 1, 1), 8.66025, 8.66025},
+		{tuple.NewPoint(1, 1, -5), tuple.NewVector(-0.5, -1, 1), 4.55006, 49.44994},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		require.Equal(t, 2, xs.Len())
+		assert.InDelta(t, tc.t0, xs.ValueAt(0), 0.00001)
+		assert.InDelta(t, tc.t1, xs.ValueAt(1), 0.00001)
+	}
+}
+
+func TestIntersectingConeWithRayParallelToOneOfItsHalves(t *testing.T) {
+	c := &Cone{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	r := ray.NewRay(tuple.NewPoint(0, 0, -1), tuple.NewVector(0, 1, 1).Normalize())
+
+	xs := c.calculateIntersections(r, &Shape{})
+
+	require.Equal(t, 1, xs.Len())
+	assert.InDelta(t, 0.35355, xs.ValueAt(0), 0.00001)
+}
+
+func TestIntersectingConeEndCaps(t *testing.T) {
+	c := &Cone{Minimum: -0.5, Maximum: 0.5, Closed: true}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		count     int
+	}{
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 1, 0), 0},
+		{tuple.NewPoint(0, 0, -0.25), tuple.NewVector(0, 1, 1), 2},
+		{tuple.NewPoint(0, 0, -0.25), tuple.NewVector(0, 1, 0), 4},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		assert.Equal(t, tc.count, xs.Len())
+	}
+}
+
+func TestNormalOnCone(t *testing.T) {
+	c := &Cone{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	cases := []struct {
+		point  *tuple.Tuple
+		normal *tuple.Tuple
+	}{
+		{tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 0)},
+		{tuple.NewPoint(1, 1, 1), tuple.NewVector(1, -math.Sqrt(2), 1)},
+		{tuple.NewPoint(-1, -1, 0), tuple.NewVector(-1, 1, 0)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.normal.Equals(c.calculateNormalAt(tc.point, nil)))
+	}
+}
+
+func TestNormalOnConeEndCaps(t *testing.T) {
+	c := &Cone{Minimum: -1, Maximum: 2, Closed: true}
+
+	assert.True(t, tuple.NewVector(0, 1, 0).Equals(c.calculateNormalAt(tuple.NewPoint(0.5, 2, 0.5), nil)))
+	assert.True(t, tuple.NewVector(0, -1, 0).Equals(c.calculateNormalAt(tuple.NewPoint(0.2, -1, 0.3), nil)))
+}
+
+func TestTruncatedConeStoresBounds(t *testing.T) {
+	s := NewTruncatedCone(-1, 2, true)
+	c := s.shapeType.(*Cone)
+
+	assert.Equal(t, -1.0, c.Minimum)
+	assert.Equal(t, 2.0, c.Maximum)
+	assert.True(t, c.Closed)
+}
@@ -0,0 +1,111 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+	"sort"
+)
+
+type Operation int
+
+const (
+	Union Operation = iota
+	Intersection
+	Difference
+)
+
+type CSG struct {
+	Operation Operation
+	Left      *Shape
+	Right     *Shape
+
+	box *Bounds
+}
+
+func NewCSG(operation Operation, left, right *Shape) *Shape {
+	s := NewShape(&CSG{Operation: operation, Left: left, Right: right})
+	left.parent = s
+	right.parent = s
+	s.refreshBounds()
+
+	return s
+}
+
+// Includes reports whether other is s itself or one of its descendants
+func (s *Shape) Includes(other *Shape) bool {
+	switch st := s.shapeType.(type) {
+	case *Group:
+		for _, child := range st.Children {
+			if child.Includes(other) {
+				return true
+			}
+		}
+		return false
+	case *CSG:
+		return st.Left.Includes(other) || st.Right.Includes(other)
+	}
+
+	return s == other
+}
+
+func intersectionAllowed(op Operation, leftHit, inLeft, inRight bool) bool {
+	switch op {
+	case Union:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case Intersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case Difference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+
+	return false
+}
+
+func (c *CSG) filterIntersections(xs *ray.Intersections) *ray.Intersections {
+	inLeft := false
+	inRight := false
+
+	result := ray.NewIntersections()
+	for _, i := range xs.GetAll() {
+		object, ok := i.Object.(*Shape)
+		leftHit := ok && c.Left.Includes(object)
+
+		if intersectionAllowed(c.Operation, leftHit, inLeft, inRight) {
+			result.Add(i)
+		}
+
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+
+	return result
+}
+
+func (c *CSG) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	leftXs := c.Left.Intersect(r)
+	rightXs := c.Right.Intersect(r)
+
+	xs := ray.NewIntersections(append(leftXs.GetAll(), rightXs.GetAll()...)...)
+	sort.Sort(xs)
+
+	return *c.filterIntersections(xs)
+}
+
+func (c *CSG) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	panic("CSG shapes have no normals of their own; the hit object is always a child")
+}
+
+func (c *CSG) bounds() *Bounds {
+	return c.box
+}
+
+func (c *CSG) calculateBounds() *Bounds {
+	b := NewEmptyBounds()
+	b.Merge(c.Left.ParentSpaceBounds())
+	b.Merge(c.Right.ParentSpaceBounds())
+
+	return b
+}
@@ -0,0 +1,189 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/transformation"
+	"goray/tuple"
+	"testing"
+)
+
+func TestCSGIsCreatedWithOperationAndTwoShapes(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewCube()
+
+	c := NewCSG(Union, s1, s2)
+
+	csg := c.shapeType.(*CSG)
+	assert.Equal(t, Union, csg.Operation)
+	assert.Equal(t, s1, csg.Left)
+	assert.Equal(t, s2, csg.Right)
+	assert.Equal(t, c, s1.GetParent())
+	assert.Equal(t, c, s2.GetParent())
+}
+
+func TestEvaluatingRuleForCSGOperation(t *testing.T) {
+	cases := []struct {
+		op                       Operation
+		leftHit, inLeft, inRight bool
+		result                   bool
+	}{
+		{Union, true, true, true, false},
+		{Union, true, true, false, true},
+		{Union, true, false, true, false},
+		{Union, true, false, false, true},
+		{Union, false, true, true, false},
+		{Union, false, true, false, false},
+		{Union, false, false, true, true},
+		{Union, false, false, false, true},
+		{Intersection, true, true, true, true},
+		{Intersection, true, true, false, false},
+		{Intersection, true, false, true, true},
+		{Intersection, true, false, false, false},
+		{Intersection, false, true, true, true},
+		{Intersection, false, true, false, true},
+		{Intersection, false, false, true, false},
+		{Intersection, false, false, false, false},
+		{Difference, true, true, true, false},
+		{Difference, true, true, false, true},
+		{Difference, true, false, true, false},
+		{Difference, true, false, false, true},
+		{Difference, false, true, true, true},
+		{Difference, false, true, false, true},
+		{Difference, false, false, true, false},
+		{Difference, false, false, false, false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.result, intersectionAllowed(tc.op, tc.leftHit, tc.inLeft, tc.inRight))
+	}
+}
+
+func TestFilteringListOfIntersections(t *testing.T) {
+	cases := []struct {
+		op     Operation
+		x0, x1 int
+	}{
+		{Union, 0, 3},
+		{Intersection, 1, 2},
+		{Difference, 0, 1},
+	}
+
+	for _, tc := range cases {
+		s1 := NewSphere()
+		s2 := NewCube()
+		c := NewCSG(tc.op, s1, s2)
+		xs := ray.NewIntersections(
+			ray.NewIntersection(1, s1),
+			ray.NewIntersection(2, s2),
+			ray.NewIntersection(3, s1),
+			ray.NewIntersection(4, s2),
+		)
+
+		result := c.shapeType.(*CSG).filterIntersections(xs)
+
+		require.Equal(t, 2, result.Len())
+		assert.Equal(t, xs.Get(tc.x0), result.Get(0))
+		assert.Equal(t, xs.Get(tc.x1), result.Get(1))
+	}
+}
+
+func TestRayMissesCSGObject(t *testing.T) {
+	c := NewCSG(Union, NewSphere(), NewCube())
+	r := ray.NewRay(tuple.NewPoint(0, 2, -5), tuple.NewVector(0, 0, 1))
+
+	xs := c.Intersect(r)
+
+	assert.Zero(t, xs.Len())
+}
+
+func TestRayHitsCSGObject(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(0, 0, 0.5))
+	c := NewCSG(Union, s1, s2)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	xs := c.Intersect(r)
+
+	require.Equal(t, 2, xs.Len())
+	assert.Equal(t, 4.0, xs.ValueAt(0))
+	assert.Equal(t, s1, xs.ObjectAt(0))
+	assert.Equal(t, 6.5, xs.ValueAt(1))
+	assert.Equal(t, s2, xs.ObjectAt(1))
+}
+
+func TestCubeWithDrilledHole(t *testing.T) {
+	cube := NewCube()
+	hole := NewCylinder()
+	hole.SetTransformation(transformation.NewScaling(0.5, 1, 0.5))
+	c := NewCSG(Difference, cube, hole)
+	r := ray.NewRay(tuple.NewPoint(-5, 0, 0), tuple.NewVector(1, 0, 0))
+
+	xs := c.Intersect(r)
+
+	require.Equal(t, 4, xs.Len())
+	assert.InDelta(t, 4.0, xs.ValueAt(0), 0.00001)
+	assert.Equal(t, cube, xs.ObjectAt(0))
+	assert.InDelta(t, 4.5, xs.ValueAt(1), 0.00001)
+	assert.Equal(t, hole, xs.ObjectAt(1))
+	assert.InDelta(t, 5.5, xs.ValueAt(2), 0.00001)
+	assert.Equal(t, hole, xs.ObjectAt(2))
+	assert.InDelta(t, 6.0, xs.ValueAt(3), 0.00001)
+	assert.Equal(t, cube, xs.ObjectAt(3))
+}
+
+func TestCSGChildrenInsideGroupsAreIncluded(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewCube()
+	g := NewGroup(s1)
+	c := NewCSG(Intersection, g, s2)
+
+	assert.True(t, c.Includes(s1))
+	assert.True(t, c.Includes(s2))
+	assert.False(t, c.Includes(NewSphere()))
+}
+
+func TestCSGInsideTransformedGroup(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(0, 0, 0.5))
+	c := NewCSG(Intersection, s1, s2)
+	g := NewGroup(c)
+	g.SetTransformation(transformation.NewTranslation(10, 0, 0))
+	r := ray.NewRay(tuple.NewPoint(10, 0, -5), tuple.NewVector(0, 0, 1))
+
+	xs := g.Intersect(r)
+
+	require.Equal(t, 2, xs.Len())
+	assert.Equal(t, 4.5, xs.ValueAt(0))
+	assert.Equal(t, s2, xs.ObjectAt(0))
+	assert.Equal(t, 6.0, xs.ValueAt(1))
+	assert.Equal(t, s1, xs.ObjectAt(1))
+	assert.True(t, tuple.NewVector(0, 0, -1).Equals(s2.NormalAt(tuple.NewPoint(10, 0, -0.5), nil)))
+}
+
+func TestCSGBoundsContainBothChildren(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(2, 3, 4))
+	c := NewCSG(Difference, s1, s2)
+
+	b := c.Bounds()
+
+	assert.True(t, tuple.NewPoint(-1, -1, -1).Equals(b.Min))
+	assert.True(t, tuple.NewPoint(3, 4, 5).Equals(b.Max))
+}
+
+func TestCSGBoundsFollowChildren(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewSphere()
+	c := NewCSG(Union, s1, s2)
+	g := NewGroup(c)
+
+	s2.SetTransformation(transformation.NewTranslation(2, 3, 4))
+
+	assert.True(t, tuple.NewPoint(3, 4, 5).Equals(c.Bounds().Max))
+	assert.True(t, tuple.NewPoint(3, 4, 5).Equals(g.Bounds().Max))
+}
@@ -0,0 +1,72 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+	"goray/utils"
+	"math"
+)
+
+type Cube struct{}
+
+func NewCube() *Shape {
+	return NewShape(Cube{})
+}
+
+func (c Cube) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	xtMin, xtMax := checkAxis(r.Origin.X, r.Direction.X, -1, 1)
+	ytMin, ytMax := checkAxis(r.Origin.Y, r.Direction.Y, -1, 1)
+	ztMin, ztMax := checkAxis(r.Origin.Z, r.Direction.Z, -1, 1)
+
+	tMin := math.Max(xtMin, math.Max(ytMin, ztMin))
+	tMax := math.Min(xtMax, math.Min(ytMax, ztMax))
+
+	if tMin > tMax {
+		return ray.Intersections{}
+	}
+
+	return *ray.NewIntersections(ray.NewIntersection(tMin, s), ray.NewIntersection(tMax, s))
+}
+
+func (c Cube) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	absX, absY, absZ := math.Abs(point.X), math.Abs(point.Y), math.Abs(point.Z)
+	maxC := math.Max(absX, math.Max(absY, absZ))
+
+	if maxC == absX {
+		return tuple.NewVector(point.X, 0, 0)
+	} else if maxC == absY {
+		return tuple.NewVector(0, point.Y, 0)
+	}
+	return tuple.NewVector(0, 0, point.Z)
+}
+
+func checkAxis(origin, direction, min, max float64) (float64, float64) {
+	tMinNumerator := min - origin
+	tMaxNumerator := max - origin
+
+	var tMin, tMax float64
+	if math.Abs(direction) >= utils.EPSILON {
+		tMin = tMinNumerator / direction
+		tMax = tMaxNumerator / direction
+	} else {
+		// a ray parallel to the slab either stays between its planes forever or
+		// never enters; a ray grazing a face counts as inside so edges still hit
+		tMin = math.Inf(1)
+		if tMinNumerator <= 0 {
+			tMin = math.Inf(-1)
+		}
+		tMax = math.Inf(-1)
+		if tMaxNumerator >= 0 {
+			tMax = math.Inf(1)
+		}
+	}
+
+	if tMin > tMax {
+		return tMax, tMin
+	}
+	return tMin, tMax
+}
+
+func (c Cube) bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1))
+}
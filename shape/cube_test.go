@@ -0,0 +1,107 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/tuple"
+	"testing"
+)
+
+func TestRayIntersectsCube(t *testing.T) {
+	c := Cube{}
+	cases := []struct {
+		name      string
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		t1, t2    float64
+	}{
+		{"+x", tuple.NewPoint(5, 0.5, 0), tuple.NewVector(-1, 0, 0), 4, 6},
+		{"-x", tuple.NewPoint(-5, 0.5, 0), tuple.NewVector(1, 0, 0), 4, 6},
+		{"+y", tuple.NewPoint(0.5, 5, 0), tuple.NewVector(0, -1, 0), 4, 6},
+		{"-y", tuple.NewPoint(0.5, -5, 0), tuple.NewVector(0, 1, 0), 4, 6},
+		{"+z", tuple.NewPoint(0.5, 0, 5), tuple.NewVector(0, 0, -1), 4, 6},
+		{"-z", tuple.NewPoint(0.5, 0, -5), tuple.NewVector(0, 0, 1), 4, 6},
+		{"inside", tuple.NewPoint(0, 0.5, 0), tuple.NewVector(0, 0, 1), -1, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := ray.NewRay(tc.origin, tc.direction)
+
+			xs := c.calculateIntersections(r, &Shape{})
+
+			require.Equal(t, 2, xs.Len())
+			assert.Equal(t, tc.t1, xs.ValueAt(0))
+			assert.Equal(t, tc.t2, xs.ValueAt(1))
+		})
+	}
+}
+
+func TestRayMissesCube(t *testing.T) {
+	c := Cube{}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+	}{
+		{tuple.NewPoint(-2, 0, 0), tuple.NewVector(0.2673, 0.5345, 0.8018)},
+		{tuple.NewPoint(0, -2, 0), tuple.NewVector(0.8018, 0.2673, 0.5345)},
+		{tuple.NewPoint(0, 0, -2), tuple.NewVector(0.5345, 0.8018, 0.2673)},
+		{tuple.NewPoint(2, 0, 2), tuple.NewVector(0, 0, -1)},
+		{tuple.NewPoint(0, 2, 2), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(2, 2, 0), tuple.NewVector(-1, 0, 0)},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction)
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		assert.Zero(t, xs.Len())
+	}
+}
+
+func TestNormalOnSurfaceOfCube(t *testing.T) {
+	c := Cube{}
+	cases := []struct {
+		point  *tuple.Tuple
+		normal *tuple.Tuple
+	}{
+		{tuple.NewPoint(1, 0.5, -0.8), tuple.NewVector(1, 0, 0)},
+		{tuple.NewPoint(-1, -0.2, 0.9), tuple.NewVector(-1, 0, 0)},
+		{tuple.NewPoint(-0.4, 1, -0.1), tuple.NewVector(0, 1, 0)},
+		{tuple.NewPoint(0.3, -1, -0.7), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(-0.6, 0.3, 1), tuple.NewVector(0, 0, 1)},
+		{tuple.NewPoint(0.4, 0.4, -1), tuple.NewVector(0, 0, -1)},
+		{tuple.NewPoint(1, 1, 1), tuple.NewVector(1, 0, 0)},
+		{tuple.NewPoint(-1, -1, -1), tuple.NewVector(-1, 0, 0)},
+	}
+
+	for _, tc := range cases {
+		n := c.calculateNormalAt(tc.point, nil)
+
+		assert.True(t, tc.normal.Equals(n))
+	}
+}
+
+func TestRayAlongCubeEdge(t *testing.T) {
+	c := Cube{}
+	r := ray.NewRay(tuple.NewPoint(1, 1, -5), tuple.NewVector(0, 0, 1))
+
+	xs := c.calculateIntersections(r, &Shape{})
+
+	require.Equal(t, 2, xs.Len())
+	assert.Equal(t, float64(4), xs.ValueAt(0))
+	assert.Equal(t, float64(6), xs.ValueAt(1))
+}
+
+func TestRayThroughCubeCorner(t *testing.T) {
+	c := Cube{}
+	r := ray.NewRay(tuple.NewPoint(-5, -5, -5), tuple.NewVector(1, 1, 1).Normalize())
+
+	xs := c.calculateIntersections(r, &Shape{})
+
+	require.Equal(t, 2, xs.Len())
+	assert.True(t, tuple.NewPoint(-1, -1, -1).Equals(r.Position(xs.ValueAt(0))))
+	assert.True(t, tuple.NewPoint(1, 1, 1).Equals(r.Position(xs.ValueAt(1))))
+}
@@ -0,0 +1,97 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+	"goray/utils"
+	"math"
+)
+
+type Cylinder struct {
+	Minimum float64
+	Maximum float64
+	Closed  bool
+}
+
+func NewCylinder() *Shape {
+	return NewShape(&Cylinder{Minimum: math.Inf(-1), Maximum: math.Inf(1), Closed: false})
+}
+
+func NewTruncatedCylinder(min, max float64, closed bool) *Shape {
+	return NewShape(&Cylinder{Minimum: min, Maximum: max, Closed: closed})
+}
+
+func (c *Cylinder) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	xs := ray.NewIntersections()
+
+	a := r.Direction.X*r.Direction.X + r.Direction.Z*r.Direction.Z
+
+	if math.Abs(a) >= utils.EPSILON {
+		b := 2*r.Origin.X*r.Direction.X + 2*r.Origin.Z*r.Direction.Z
+		cc := r.Origin.X*r.Origin.X + r.Origin.Z*r.Origin.Z - 1
+
+		discriminant := b*b - 4*a*cc
+		if discriminant < 0 {
+			return ray.Intersections{}
+		}
+
+		t0 := (-b - math.Sqrt(discriminant)) / (2 * a)
+		t1 := (-b + math.Sqrt(discriminant)) / (2 * a)
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+
+		for _, t := range []float64{t0, t1} {
+			y := r.Origin.Y + t*r.Direction.Y
+			if c.Minimum < y && y < c.Maximum {
+				xs.Add(ray.NewIntersection(t, s))
+			}
+		}
+	}
+
+	if c.Closed {
+		intersectCaps(r, s, xs, c.Minimum, c.Maximum, func(y float64) float64 {
+			return 1
+		})
+	}
+
+	return *xs
+}
+
+func (c *Cylinder) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	dist := point.X*point.X + point.Z*point.Z
+
+	if dist < 1 && point.Y >= c.Maximum-utils.EPSILON {
+		return tuple.NewVector(0, 1, 0)
+	} else if dist < 1 && point.Y <= c.Minimum+utils.EPSILON {
+		return tuple.NewVector(0, -1, 0)
+	}
+
+	return tuple.NewVector(point.X, 0, point.Z)
+}
+
+// intersectCaps adds the intersections with the end caps at min and max,
+// where radius gives the cap radius at a given y
+func intersectCaps(r *ray.Ray, s *Shape, xs *ray.Intersections, min, max float64, radius func(y float64) float64) {
+	if math.Abs(r.Direction.Y) < utils.EPSILON {
+		return
+	}
+
+	for _, y := range []float64{min, max} {
+		t := (y - r.Origin.Y) / r.Direction.Y
+		if checkCap(r, t, radius(y)) {
+			xs.Add(ray.NewIntersection(t, s))
+		}
+	}
+}
+
+func checkCap(r *ray.Ray, t, radius float64) bool {
+	x := r.Origin.X + t*r.Direction.X
+	z := r.Origin.Z + t*r.Direction.Z
+
+	return x*x+z*z <= radius*radius
+}
+
+func (c *Cylinder) bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, c.Minimum, -1), tuple.NewPoint(1, c.Maximum, 1))
+}
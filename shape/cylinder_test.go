@@ -0,0 +1,145 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestRayMissesCylinder(t *testing.T) {
+	c := &Cylinder{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+	}{
+		{tuple.NewPoint(1, 0, 0), tuple.NewVector(0, 1, 0)},
+		{tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(1, 1, 1)},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		assert.Zero(t, xs.Len())
+	}
+}
+
+func TestRayStrikesCylinder(t *testing.T) {
+	c := &Cylinder{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		t0, t1    float64
+	}{
+		{tuple.NewPoint(1, 0, -5), tuple.NewVector(0, 0, 1), 5, 5},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), 4, 6},
+		{tuple.NewPoint(0.5, 0, -5), tuple.NewVector(0.1, 1, 1), 6.80798, 7.08872},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		require.Equal(t, 2, xs.Len())
+		assert.InDelta(t, tc.t0, xs.ValueAt(0), 0.00001)
+		assert.InDelta(t, tc.t1, xs.ValueAt(1), 0.00001)
+	}
+}
+
+func TestNormalOnCylinder(t *testing.T) {
+	c := &Cylinder{Minimum: math.Inf(-1), Maximum: math.Inf(1)}
+	cases := []struct {
+		point  *tuple.Tuple
+		normal *tuple.Tuple
+	}{
+		{tuple.NewPoint(1, 0, 0), tuple.NewVector(1, 0, 0)},
+		{tuple.NewPoint(0, 5, -1), tuple.NewVector(0, 0, -1)},
+		{tuple.NewPoint(0, -2, 1), tuple.NewVector(0, 0, 1)},
+		{tuple.NewPoint(-1, 1, 0), tuple.NewVector(-1, 0, 0)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.normal.Equals(c.calculateNormalAt(tc.point, nil)))
+	}
+}
+
+func TestDefaultCylinderIsInfiniteAndOpen(t *testing.T) {
+	s := NewCylinder()
+	c := s.shapeType.(*Cylinder)
+
+	assert.Equal(t, math.Inf(-1), c.Minimum)
+	assert.Equal(t, math.Inf(1), c.Maximum)
+	assert.False(t, c.Closed)
+}
+
+func TestIntersectingConstrainedCylinder(t *testing.T) {
+	c := &Cylinder{Minimum: 1, Maximum: 2}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		count     int
+	}{
+		{tuple.NewPoint(0, 1.5, 0), tuple.NewVector(0.1, 1, 0), 0},
+		{tuple.NewPoint(0, 3, -5), tuple.NewVector(0, 0, 1), 0},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), 0},
+		{tuple.NewPoint(0, 2, -5), tuple.NewVector(0, 0, 1), 0},
+		{tuple.NewPoint(0, 1, -5), tuple.NewVector(0, 0, 1), 0},
+		{tuple.NewPoint(0, 1.5, -2), tuple.NewVector(0, 0, 1), 2},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		assert.Equal(t, tc.count, xs.Len())
+	}
+}
+
+func TestIntersectingCapsOfClosedCylinder(t *testing.T) {
+	c := &Cylinder{Minimum: 1, Maximum: 2, Closed: true}
+	cases := []struct {
+		origin    *tuple.Tuple
+		direction *tuple.Tuple
+		count     int
+	}{
+		{tuple.NewPoint(0, 3, 0), tuple.NewVector(0, -1, 0), 2},
+		{tuple.NewPoint(0, 3, -2), tuple.NewVector(0, -1, 2), 2},
+		{tuple.NewPoint(0, 4, -2), tuple.NewVector(0, -1, 1), 2},
+		{tuple.NewPoint(0, 0, -2), tuple.NewVector(0, 1, 2), 2},
+		{tuple.NewPoint(0, -1, -2), tuple.NewVector(0, 1, 1), 2},
+	}
+
+	for _, tc := range cases {
+		r := ray.NewRay(tc.origin, tc.direction.Normalize())
+
+		xs := c.calculateIntersections(r, &Shape{})
+
+		assert.Equal(t, tc.count, xs.Len())
+	}
+}
+
+func TestNormalOnCylinderEndCaps(t *testing.T) {
+	c := &Cylinder{Minimum: 1, Maximum: 2, Closed: true}
+	cases := []struct {
+		point  *tuple.Tuple
+		normal *tuple.Tuple
+	}{
+		{tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(0.5, 1, 0), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(0, 1, 0.5), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(0, 2, 0), tuple.NewVector(0, 1, 0)},
+		{tuple.NewPoint(0.5, 2, 0), tuple.NewVector(0, 1, 0)},
+		{tuple.NewPoint(0, 2, 0.5), tuple.NewVector(0, 1, 0)},
+	}
+
+	for _, tc := range cases {
+		assert.True(t, tc.normal.Equals(c.calculateNormalAt(tc.point, nil)))
+	}
+}
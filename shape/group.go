@@ -0,0 +1,134 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+	"sort"
+)
+
+type Group struct {
+	Children []*Shape
+
+	box *Bounds
+}
+
+func NewGroup(children ...*Shape) *Shape {
+	g := NewShape(&Group{box: NewEmptyBounds()})
+	for _, child := range children {
+		g.AddChild(child)
+	}
+
+	return g
+}
+
+func (s *Shape) AddChild(child *Shape) {
+	g, ok := s.shapeType.(*Group)
+	if !ok {
+		panic("trying to add a child to a shape that is not a group")
+	}
+
+	child.parent = s
+	g.Children = append(g.Children, child)
+	s.growBounds(child.ParentSpaceBounds())
+}
+
+// Children returns the shapes in a group, or nil when s is not a group
+func (s *Shape) Children() []*Shape {
+	if g, ok := s.shapeType.(*Group); ok {
+		return g.Children
+	}
+
+	return nil
+}
+
+// Divide turns a group into a bounding volume hierarchy by recursively
+// moving its children into subgroups of at most threshold shapes
+func (s *Shape) Divide(threshold int) {
+	if c, ok := s.shapeType.(*CSG); ok {
+		c.Left.Divide(threshold)
+		c.Right.Divide(threshold)
+		return
+	}
+
+	g, ok := s.shapeType.(*Group)
+	if !ok {
+		return
+	}
+
+	if threshold <= len(g.Children) {
+		left, right := g.partitionChildren()
+		if len(left) > 0 {
+			s.AddChild(NewGroup(left...))
+		}
+		if len(right) > 0 {
+			s.AddChild(NewGroup(right...))
+		}
+	}
+
+	for _, child := range g.Children {
+		child.Divide(threshold)
+	}
+}
+
+// partitionChildren removes and returns the children that fit entirely in
+// either half of the group's bounds; the ones straddling the split stay put
+func (g *Group) partitionChildren() ([]*Shape, []*Shape) {
+	leftBox, rightBox := g.box.Split()
+
+	var left, right, remaining []*Shape
+	for _, child := range g.Children {
+		childBox := child.ParentSpaceBounds()
+
+		if leftBox.ContainsBounds(childBox) {
+			left = append(left, child)
+		} else if rightBox.ContainsBounds(childBox) {
+			right = append(right, child)
+		} else {
+			remaining = append(remaining, child)
+		}
+	}
+
+	// a split that does not separate anything would recurse forever
+	if len(left) == len(g.Children) || len(right) == len(g.Children) {
+		return nil, nil
+	}
+
+	g.Children = remaining
+
+	return left, right
+}
+
+func (g *Group) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	if !g.box.Intersects(r) {
+		return ray.Intersections{}
+	}
+
+	xs := ray.NewIntersections()
+	for _, child := range g.Children {
+		childXs := child.Intersect(r)
+		for _, x := range childXs.GetAll() {
+			xs.Add(x)
+		}
+	}
+
+	sort.Sort(xs)
+
+	return *xs
+}
+
+func (g *Group) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	panic("groups have no normals of their own; the hit object is always a child")
+}
+
+func (g *Group) bounds() *Bounds {
+	return g.box
+}
+
+func (g *Group) calculateBounds() *Bounds {
+	b := NewEmptyBounds()
+	for _, child := range g.Children {
+		b.Merge(child.ParentSpaceBounds())
+	}
+
+	return b
+}
@@ -0,0 +1,281 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/matrix"
+	"goray/ray"
+	"goray/transformation"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestCreatingNewGroup(t *testing.T) {
+	g := NewGroup()
+
+	assert.True(t, matrix.NewIdentityMatrix4x4().Equals(g.GetTransformation()))
+	assert.Empty(t, g.shapeType.(*Group).Children)
+}
+
+func TestShapeHasParentAttribute(t *testing.T) {
+	s := NewTestShape()
+
+	assert.Nil(t, s.GetParent())
+}
+
+func TestAddingChildToGroup(t *testing.T) {
+	g := NewGroup()
+	s := NewTestShape()
+
+	g.AddChild(s)
+
+	assert.Contains(t, g.shapeType.(*Group).Children, s)
+	assert.Equal(t, g, s.GetParent())
+}
+
+func TestAddingChildToNonGroupPanics(t *testing.T) {
+	s := NewSphere()
+
+	assert.Panics(t, func() {
+		s.AddChild(NewSphere())
+	})
+}
+
+func TestIntersectingRayWithEmptyGroup(t *testing.T) {
+	g := NewGroup()
+	r := ray.NewRay(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+
+	xs := g.Intersect(r)
+
+	assert.Zero(t, xs.Len())
+}
+
+func TestIntersectingRayWithNonemptyGroup(t *testing.T) {
+	s1 := NewSphere()
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(0, 0, -3))
+	s3 := NewSphere()
+	s3.SetTransformation(transformation.NewTranslation(5, 0, 0))
+	g := NewGroup(s1, s2, s3)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	xs := g.Intersect(r)
+
+	require.Equal(t, 4, xs.Len())
+	assert.Equal(t, s2, xs.ObjectAt(0))
+	assert.Equal(t, s2, xs.ObjectAt(1))
+	assert.Equal(t, s1, xs.ObjectAt(2))
+	assert.Equal(t, s1, xs.ObjectAt(3))
+}
+
+func TestIntersectingTransformedGroup(t *testing.T) {
+	s := NewSphere()
+	s.SetTransformation(transformation.NewTranslation(5, 0, 0))
+	g := NewGroup(s)
+	g.SetTransformation(transformation.NewScaling(2, 2, 2))
+	r := ray.NewRay(tuple.NewPoint(10, 0, -10), tuple.NewVector(0, 0, 1))
+
+	xs := g.Intersect(r)
+
+	assert.Equal(t, 2, xs.Len())
+}
+
+func TestConvertingPointFromWorldToObjectSpaceThroughGroups(t *testing.T) {
+	s := NewSphere()
+	s.SetTransformation(transformation.NewTranslation(5, 0, 0))
+	g2 := NewGroup(s)
+	g2.SetTransformation(transformation.NewScaling(2, 2, 2))
+	g1 := NewGroup(g2)
+	g1.SetTransformation(transformation.NewRotationY(math.Pi / 2))
+
+	p := s.WorldToObject(tuple.NewPoint(-2, 0, -10))
+
+	assert.True(t, tuple.NewPoint(0, 0, -1).Equals(p))
+}
+
+func TestConvertingNormalFromObjectToWorldSpace(t *testing.T) {
+	s := NewSphere()
+	s.SetTransformation(transformation.NewTranslation(5, 0, 0))
+	g2 := NewGroup(s)
+	g2.SetTransformation(transformation.NewScaling(1, 2, 3))
+	g1 := NewGroup(g2)
+	g1.SetTransformation(transformation.NewRotationY(math.Pi / 2))
+
+	n := s.NormalToWorld(tuple.NewVector(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3))
+
+	assert.True(t, tuple.NewVector(0.28571, 0.42857, -0.85714).Equals(n))
+}
+
+func TestFindingNormalOnChildObject(t *testing.T) {
+	s := NewSphere()
+	s.SetTransformation(transformation.NewTranslation(5, 0, 0))
+	g2 := NewGroup(s)
+	g2.SetTransformation(transformation.NewScaling(1, 2, 3))
+	g1 := NewGroup(g2)
+	g1.SetTransformation(transformation.NewRotationY(math.Pi / 2))
+
+	n := s.NormalAt(tuple.NewPoint(1.7321, 1.1547, -5.5774), nil)
+
+	assert.True(t, tuple.NewVector(0.2857, 0.42854, -0.85716).Equals(n))
+}
+
+func TestGroupHasBoundsThatContainItsChildren(t *testing.T) {
+	s := NewSphere()
+	s.SetTransformation(transformation.NewTranslation(2, 5, -3).MultiplyMatrix(transformation.NewScaling(2, 2, 2)))
+	c := NewTruncatedCylinder(-2, 2, false)
+	c.SetTransformation(transformation.NewTranslation(-4, -1, 4).MultiplyMatrix(transformation.NewScaling(0.5, 1, 0.5)))
+
+	g := NewGroup(s, c)
+	b := g.Bounds()
+
+	assert.True(t, tuple.NewPoint(-4.5, -3, -5).Equals(b.Min))
+	assert.True(t, tuple.NewPoint(4, 7, 4.5).Equals(b.Max))
+}
+
+func TestGroupBoundsFollowChildTransformation(t *testing.T) {
+	s := NewSphere()
+	g := NewGroup(s)
+
+	s.SetTransformation(transformation.NewTranslation(10, 0, 0))
+
+	assert.True(t, tuple.NewPoint(9, -1, -1).Equals(g.Bounds().Min))
+	assert.True(t, tuple.NewPoint(11, 1, 1).Equals(g.Bounds().Max))
+}
+
+func TestIntersectingRayMissingGroupBoundsSkipsChildren(t *testing.T) {
+	child := NewTestShape()
+	calls := 0
+	child.shapeType = &countingShape{calls: &calls}
+	g := NewGroup(child)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 1, 0))
+
+	g.Intersect(r)
+
+	assert.Zero(t, calls)
+}
+
+func TestIntersectingRayHittingGroupBoundsTestsChildren(t *testing.T) {
+	child := NewTestShape()
+	calls := 0
+	child.shapeType = &countingShape{calls: &calls}
+	g := NewGroup(child)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	g.Intersect(r)
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestPartitioningChildrenOfGroup(t *testing.T) {
+	s1 := NewSphere()
+	s1.SetTransformation(transformation.NewTranslation(-2, 0, 0))
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(2, 0, 0))
+	s3 := NewSphere()
+	g := NewGroup(s1, s2, s3)
+
+	left, right := g.shapeType.(*Group).partitionChildren()
+
+	assert.Equal(t, []*Shape{s3}, g.shapeType.(*Group).Children)
+	assert.Equal(t, []*Shape{s1}, left)
+	assert.Equal(t, []*Shape{s2}, right)
+}
+
+func TestSubdividingGroup(t *testing.T) {
+	s1 := NewSphere()
+	s1.SetTransformation(transformation.NewTranslation(-2, -2, 0))
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(-2, 2, 0))
+	s3 := NewSphere()
+	s3.SetTransformation(transformation.NewScaling(4, 4, 4))
+	g := NewGroup(s1, s2, s3)
+
+	g.Divide(1)
+
+	children := g.shapeType.(*Group).Children
+	require.Len(t, children, 2)
+	assert.Equal(t, s3, children[0])
+	subgroup := children[1].shapeType.(*Group)
+	require.Len(t, subgroup.Children, 2)
+	assert.Equal(t, []*Shape{s1}, subgroup.Children[0].shapeType.(*Group).Children)
+	assert.Equal(t, []*Shape{s2}, subgroup.Children[1].shapeType.(*Group).Children)
+}
+
+func TestSubdividingGroupWithTooFewChildren(t *testing.T) {
+	s1 := NewSphere()
+	s1.SetTransformation(transformation.NewTranslation(-2, 0, 0))
+	s2 := NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(2, 1, 0))
+	s3 := NewSphere()
+	s3.SetTransformation(transformation.NewTranslation(2, -1, 0))
+	subgroup := NewGroup(s1, s2, s3)
+	s4 := NewSphere()
+	g := NewGroup(subgroup, s4)
+
+	g.Divide(3)
+
+	children := g.shapeType.(*Group).Children
+	require.Len(t, children, 2)
+	assert.Equal(t, subgroup, children[0])
+	assert.Equal(t, s4, children[1])
+	sub := subgroup.shapeType.(*Group).Children
+	require.Len(t, sub, 2)
+	assert.Equal(t, []*Shape{s1}, sub[0].shapeType.(*Group).Children)
+	assert.Equal(t, []*Shape{s2, s3}, sub[1].shapeType.(*Group).Children)
+}
+
+func TestDividingGroupOfInfiniteShapesTerminates(t *testing.T) {
+	g := NewGroup(NewPlane(), NewPlane(), NewCylinder())
+
+	g.Divide(1)
+
+	assert.Len(t, g.shapeType.(*Group).Children, 3)
+}
+
+type countingShape struct {
+	calls *int
+}
+
+func (cs *countingShape) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	*cs.calls++
+	return ray.Intersections{}
+}
+
+func (cs *countingShape) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	return tuple.NewVector(point.X, point.Y, point.Z)
+}
+
+func (cs *countingShape) bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1))
+}
+
+type boundsCountingShape struct {
+	countingShape
+	boundsCalls *int
+}
+
+func (bs *boundsCountingShape) bounds() *Bounds {
+	*bs.boundsCalls++
+	return bs.countingShape.bounds()
+}
+
+func TestBuildingAndDividingLargeGroupScalesLinearlyInBoundsLookups(t *testing.T) {
+	const n = 1024
+	calls := 0
+	children := make([]*Shape, n)
+	for i := range children {
+		children[i] = NewShape(&boundsCountingShape{countingShape{new(int)}, &calls})
+		children[i].SetTransformation(transformation.NewTranslation(float64(3*i), 0, 0))
+	}
+
+	calls = 0
+	g := NewGroup(children...)
+	assert.Equal(t, n, calls)
+
+	calls = 0
+	g.Divide(1)
+	// each level of the hierarchy looks at every child a couple of times;
+	// rebuilding bounds from all siblings on every AddChild would be quadratic
+	assert.Less(t, calls, 4*n*int(math.Log2(n)))
+}
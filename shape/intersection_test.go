@@ -6,6 +6,7 @@ import (
 	"goray/transformation"
 	"goray/tuple"
 	"goray/utils"
+	"math"
 	"testing"
 )
 
@@ -18,6 +19,15 @@ func TestIntersectionHasTAndObject(t *testing.T) {
 	assert.Equal(t, s, i.Object)
 }
 
+func TestIntersectionEncapsulatesUAndV(t *testing.T) {
+	s := NewTriangle(tuple.NewPoint(0, 1, 0), tuple.NewPoint(-1, 0, 0), tuple.NewPoint(1, 0, 0))
+
+	i := ray.NewIntersectionWithUV(3.5, s, 0.2, 0.4)
+
+	assert.Equal(t, 0.2, i.U)
+	assert.Equal(t, 0.4, i.V)
+}
+
 func TestAggregatingIntersections(t *testing.T) {
 	s := NewSphere()
 
@@ -86,7 +96,7 @@ func TestPrecomputingStateOfIntersection(t *testing.T) {
 	s := NewSphere()
 	i := ray.NewIntersection(4, s)
 
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
 
 	assert.Equal(t, i.T, comps.T)
 	assert.Equal(t, i.Object, comps.Object)
@@ -100,7 +110,7 @@ func TestHitWithIntersectionOnTheOutside(t *testing.T) {
 	s := NewSphere()
 	i := ray.NewIntersection(4, s)
 
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
 
 	assert.False(t, comps.Inside)
 }
@@ -110,7 +120,7 @@ func TestHitWithIntersectionOnTheInside(t *testing.T) {
 	s := NewSphere()
 	i := ray.NewIntersection(1, s)
 
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
 
 	assert.True(t, tuple.NewPoint(0, 0, 1).Equals(comps.Point))
 	assert.True(t, tuple.NewVector(0, 0, -1).Equals(comps.EyeV))
@@ -125,8 +135,91 @@ func TestHitShouldOffsetThePoint(t *testing.T) {
 
 	i := ray.NewIntersection(5, s)
 
-	comps := i.PrepareComputations(r)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
 
 	assert.Less(t, comps.OverPoint.Z, -utils.EPSILON/2)
 	assert.Greater(t, comps.Point.Z, comps.OverPoint.Z)
 }
+
+func TestPrecomputingReflectionVector(t *testing.T) {
+	s := NewPlane()
+	r := ray.NewRay(tuple.NewPoint(0, 1, -1), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := ray.NewIntersection(math.Sqrt(2), s)
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+
+	assert.True(t, tuple.NewVector(0, math.Sqrt(2)/2, math.Sqrt(2)/2).Equals(comps.ReflectV))
+}
+
+func TestFindingN1AndN2AtVariousIntersections(t *testing.T) {
+	a := NewGlassSphere()
+	a.SetTransformation(transformation.NewScaling(2, 2, 2))
+	a.GetMaterial().RefractiveIndex = 1.5
+	b := NewGlassSphere()
+	b.SetTransformation(transformation.NewTranslation(0, 0, -0.25))
+	b.GetMaterial().RefractiveIndex = 2.0
+	c := NewGlassSphere()
+	c.SetTransformation(transformation.NewTranslation(0, 0, 0.25))
+	c.GetMaterial().RefractiveIndex = 2.5
+	r := ray.NewRay(tuple.NewPoint(0, 0, -4), tuple.NewVector(0, 0, 1))
+	xs := ray.NewIntersections(
+		ray.NewIntersection(2, a),
+		ray.NewIntersection(2.75, b),
+		ray.NewIntersection(3.25, c),
+		ray.NewIntersection(4.75, b),
+		ray.NewIntersection(5.25, c),
+		ray.NewIntersection(6, a),
+	)
+
+	expected := [][2]float64{{1.0, 1.5}, {1.5, 2.0}, {2.0, 2.5}, {2.5, 2.5}, {2.5, 1.5}, {1.5, 1.0}}
+
+	for index, n := range expected {
+		comps := xs.Get(index).PrepareComputations(r, xs)
+
+		assert.Equal(t, n[0], comps.N1)
+		assert.Equal(t, n[1], comps.N2)
+	}
+}
+
+func TestUnderPointIsOffsetBelowTheSurface(t *testing.T) {
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	s := NewGlassSphere()
+	s.SetTransformation(transformation.NewTranslation(0, 0, 1))
+	i := ray.NewIntersection(5, s)
+	xs := ray.NewIntersections(i)
+
+	comps := i.PrepareComputations(r, xs)
+
+	assert.Greater(t, comps.UnderPoint.Z, utils.EPSILON/2)
+	assert.Less(t, comps.Point.Z, comps.UnderPoint.Z)
+}
+
+func TestSchlickApproximationUnderTotalInternalReflection(t *testing.T) {
+	s := NewGlassSphere()
+	r := ray.NewRay(tuple.NewPoint(0, 0, math.Sqrt(2)/2), tuple.NewVector(0, 1, 0))
+	xs := ray.NewIntersections(ray.NewIntersection(-math.Sqrt(2)/2, s), ray.NewIntersection(math.Sqrt(2)/2, s))
+
+	comps := xs.Get(1).PrepareComputations(r, xs)
+
+	assert.Equal(t, 1.0, comps.Schlick())
+}
+
+func TestSchlickApproximationWithPerpendicularViewingAngle(t *testing.T) {
+	s := NewGlassSphere()
+	r := ray.NewRay(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0))
+	xs := ray.NewIntersections(ray.NewIntersection(-1, s), ray.NewIntersection(1, s))
+
+	comps := xs.Get(1).PrepareComputations(r, xs)
+
+	assert.InDelta(t, 0.04, comps.Schlick(), utils.EPSILON)
+}
+
+func TestSchlickApproximationWithSmallAngleAndN2GreaterThanN1(t *testing.T) {
+	s := NewGlassSphere()
+	r := ray.NewRay(tuple.NewPoint(0, 0.99, -2), tuple.NewVector(0, 0, 1))
+	xs := ray.NewIntersections(ray.NewIntersection(1.8589, s))
+
+	comps := xs.Get(0).PrepareComputations(r, xs)
+
+	assert.InDelta(t, 0.48873, comps.Schlick(), utils.EPSILON)
+}
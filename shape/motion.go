@@ -0,0 +1,126 @@
+package shape
+
+import (
+	"goray/matrix"
+	"goray/transformation"
+	"goray/tuple"
+	"math"
+	"sort"
+)
+
+// motionBoundsSteps is how many poses per keyframe segment are merged into
+// the bounds of a moving shape; the box is then padded by how far a rotation
+// can sweep past the sampled poses, so more steps only make it tighter
+const motionBoundsSteps = 32
+
+type Keyframe struct {
+	Time           float64
+	Transformation *matrix.Matrix
+}
+
+// SetMotion moves the shape from start at time 0 to end at time 1
+func (s *Shape) SetMotion(start, end *matrix.Matrix) {
+	s.SetKeyframes(Keyframe{Time: 0, Transformation: start}, Keyframe{Time: 1, Transformation: end})
+}
+
+// SetKeyframes animates the shape through the given poses, interpolating
+// between them and holding the first and last pose outside their range. A
+// single keyframe places the shape statically; none leaves it where it is
+// and stops any motion
+func (s *Shape) SetKeyframes(frames ...Keyframe) {
+	s.keyframes = nil
+	s.motion = nil
+	if len(frames) == 0 {
+		return
+	}
+
+	sorted := append([]Keyframe(nil), frames...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+
+	if len(sorted) > 1 {
+		s.keyframes = sorted
+		s.motion = make([]*transformation.Interpolator, len(sorted)-1)
+		for i := range s.motion {
+			s.motion[i] = transformation.NewInterpolator(sorted[i].Transformation, sorted[i+1].Transformation)
+		}
+	}
+	s.setTransformation(sorted[0].Transformation)
+}
+
+func (s *Shape) GetKeyframes() []Keyframe {
+	return s.keyframes
+}
+
+func (s *Shape) IsMoving() bool {
+	return len(s.keyframes) > 1
+}
+
+func (s *Shape) TransformationAt(time float64) *matrix.Matrix {
+	if !s.IsMoving() {
+		return s.transformation
+	}
+
+	segment, t := s.segmentAt(time)
+	return segment.At(t)
+}
+
+func (s *Shape) inverseAt(time float64) *matrix.Matrix {
+	if !s.IsMoving() {
+		return s.inverse
+	}
+
+	segment, t := s.segmentAt(time)
+	return segment.InverseAt(t)
+}
+
+func (s *Shape) inverseTransposeAt(time float64) *matrix.Matrix {
+	if !s.IsMoving() {
+		return s.inverseTranspose
+	}
+
+	return s.inverseAt(time).Transpose()
+}
+
+// segmentAt finds the pair of keyframes around time and how far time lies
+// between them
+func (s *Shape) segmentAt(time float64) (*transformation.Interpolator, float64) {
+	frames := s.keyframes
+	if time <= frames[0].Time {
+		return s.motion[0], 0
+	}
+
+	for i := 1; i < len(frames); i++ {
+		if time < frames[i].Time {
+			prev := frames[i-1]
+			return s.motion[i-1], (time - prev.Time) / (frames[i].Time - prev.Time)
+		}
+	}
+
+	return s.motion[len(s.motion)-1], 1
+}
+
+// motionBounds covers every pose the shape passes through
+func (s *Shape) motionBounds() *Bounds {
+	box := NewEmptyBounds()
+	objectBounds := s.Bounds()
+
+	for _, segment := range s.motion {
+		swept := NewEmptyBounds()
+		for step := 0; step <= motionBoundsSteps; step++ {
+			t := float64(step) / motionBoundsSteps
+
+			swept.Merge(objectBounds.Transform(segment.At(t)))
+		}
+
+		pad := segment.MaxChordDeviation(objectBounds.Min, objectBounds.Max, motionBoundsSteps)
+		if pad > 0 && !math.IsInf(pad, 0) && !math.IsNaN(pad) && !swept.IsEmpty() {
+			swept = NewBounds(
+				tuple.NewPoint(swept.Min.X-pad, swept.Min.Y-pad, swept.Min.Z-pad),
+				tuple.NewPoint(swept.Max.X+pad, swept.Max.Y+pad, swept.Max.Z+pad),
+			)
+		}
+		box.Merge(swept)
+	}
+
+	return box
+}
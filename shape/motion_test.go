@@ -0,0 +1,139 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/transformation"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func newMovingSphere() *Shape {
+	s := NewSphere()
+	s.SetMotion(transformation.NewTranslation(0, 0, 0), transformation.NewTranslation(4, 0, 0))
+
+	return s
+}
+
+func TestStaticShapeIsNotMoving(t *testing.T) {
+	s := NewSphere()
+	s.SetKeyframes(Keyframe{Time: 0.5, Transformation: transformation.NewTranslation(1, 0, 0)})
+
+	assert.False(t, s.IsMoving())
+	assert.True(t, transformation.NewTranslation(1, 0, 0).Equals(s.TransformationAt(0.9)))
+}
+
+func TestNoKeyframesStopsMotion(t *testing.T) {
+	s := newMovingSphere()
+
+	s.SetKeyframes()
+
+	assert.False(t, s.IsMoving())
+	assert.True(t, transformation.NewTranslation(0, 0, 0).Equals(s.TransformationAt(1)))
+}
+
+func TestIntersectingMovingShapeAtRayTime(t *testing.T) {
+	s := newMovingSphere()
+	r := ray.NewRay(tuple.NewPoint(2, 0, -5), tuple.NewVector(0, 0, 1))
+
+	r.Time = 0
+	none := s.Intersect(r)
+	assert.Equal(t, 0, none.Len())
+
+	r.Time = 0.5
+	xs := s.Intersect(r)
+	require.Equal(t, 2, xs.Len())
+	assert.Equal(t, 4.0, xs.Get(0).T)
+	assert.Equal(t, 0.5, xs.Get(0).Time)
+}
+
+func TestNormalOnMovingShapeUsesHitTime(t *testing.T) {
+	s := NewSphere()
+	s.SetMotion(transformation.NewRotationZ(0), transformation.NewRotationZ(math.Pi/2).MultiplyMatrix(transformation.NewScaling(1, 2, 1)))
+	hit := ray.NewIntersection(1, s)
+	hit.Time = 1
+
+	n := s.NormalAt(tuple.NewPoint(-math.Sqrt(2), math.Sqrt(2)/2, 0), hit)
+
+	expected := s.TransformationAt(1).Invert().Transpose().MultiplyTuple(tuple.NewVector(math.Sqrt(2)/2, math.Sqrt(2)/2, 0))
+	expected.W = 0
+	assert.True(t, expected.Normalize().Equals(n))
+}
+
+func TestKeyframesAreSortedAndClamped(t *testing.T) {
+	s := NewSphere()
+	s.SetKeyframes(
+		Keyframe{Time: 1, Transformation: transformation.NewTranslation(2, 0, 0)},
+		Keyframe{Time: 0, Transformation: transformation.NewTranslation(0, 0, 0)},
+		Keyframe{Time: 0.5, Transformation: transformation.NewTranslation(0, 6, 0)},
+	)
+
+	assert.True(t, transformation.NewTranslation(0, 0, 0).Equals(s.TransformationAt(-1)))
+	assert.True(t, transformation.NewTranslation(0, 3, 0).Equals(s.TransformationAt(0.25)))
+	assert.True(t, transformation.NewTranslation(1, 3, 0).Equals(s.TransformationAt(0.75)))
+	assert.True(t, transformation.NewTranslation(2, 0, 0).Equals(s.TransformationAt(3)))
+	assert.True(t, transformation.NewTranslation(0, 0, 0).Equals(s.GetTransformation()))
+}
+
+func TestSettingTransformationStopsMotion(t *testing.T) {
+	s := newMovingSphere()
+
+	s.SetTransformation(transformation.NewTranslation(0, 1, 0))
+
+	assert.False(t, s.IsMoving())
+	assert.True(t, transformation.NewTranslation(0, 1, 0).Equals(s.TransformationAt(1)))
+}
+
+func TestBoundsOfMovingShapeCoverWholeMotion(t *testing.T) {
+	s := newMovingSphere()
+
+	b := s.ParentSpaceBounds()
+
+	assert.True(t, tuple.NewPoint(-1, -1, -1).Equals(b.Min))
+	assert.True(t, tuple.NewPoint(5, 1, 1).Equals(b.Max))
+}
+
+func TestBoundsOfRotatingShapeCoverCornersBetweenSamples(t *testing.T) {
+	// the corner at (1, y, 1) swings furthest along +x after turning 45
+	// degrees, which this angle puts halfway between two sampled poses
+	angle := math.Pi / 4 * motionBoundsSteps / (motionBoundsSteps/2 + 0.5)
+	s := NewCube()
+	s.SetMotion(transformation.NewRotationY(0), transformation.NewRotationY(angle))
+	peak := math.Pi / 4 / angle
+
+	b := s.ParentSpaceBounds()
+
+	corner := s.TransformationAt(peak).MultiplyTuple(tuple.NewPoint(1, 1, 1))
+	assert.InDelta(t, math.Sqrt2, corner.X, 0.00001)
+	assert.True(t, b.ContainsPoint(corner), "%v not in %v..%v", corner, b.Min, b.Max)
+
+	g := NewGroup(s)
+	r := ray.NewRay(tuple.NewPoint(math.Sqrt2-0.0001, 0, -5), tuple.NewVector(0, 0, 1))
+	r.Time = peak
+	xs := g.Intersect(r)
+	assert.Equal(t, 2, xs.Len())
+}
+
+func TestGroupFindsMovingChildAnywhereAlongItsPath(t *testing.T) {
+	s := newMovingSphere()
+	g := NewGroup(s)
+	r := ray.NewRay(tuple.NewPoint(4, 0, -5), tuple.NewVector(0, 0, 1))
+	r.Time = 1
+
+	xs := g.Intersect(r)
+
+	require.Equal(t, 2, xs.Len())
+	assert.Equal(t, s, xs.Get(0).Object)
+}
+
+func TestWorldToObjectOnMovingShapeInsideMovingGroup(t *testing.T) {
+	s := newMovingSphere()
+	g := NewGroup(s)
+	g.SetMotion(transformation.NewTranslation(0, 0, 0), transformation.NewTranslation(0, 2, 0))
+
+	p := s.WorldToObjectAt(tuple.NewPoint(3, 1, 0), 0.5)
+
+	assert.True(t, tuple.NewPoint(1, 0, 0).Equals(p))
+}
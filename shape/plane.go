@@ -22,6 +22,10 @@ func (p Plane) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
 	return *ray.NewIntersections(ray.NewIntersection(t, s))
 }
 
-func (p Plane) calculateNormalAt(point *tuple.Tuple) *tuple.Tuple {
+func (p Plane) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
 	return tuple.NewVector(0, 1, 0)
 }
+
+func (p Plane) bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(math.Inf(-1), 0, math.Inf(-1)), tuple.NewPoint(math.Inf(1), 0, math.Inf(1)))
+}
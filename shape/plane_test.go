@@ -10,9 +10,9 @@ import (
 func TestNormalOfPlaneIsConstantEverywhere(t *testing.T) {
 	p := Plane{}
 
-	n1 := p.calculateNormalAt(tuple.NewPoint(0, 0, 0))
-	n2 := p.calculateNormalAt(tuple.NewPoint(10, 0, -10))
-	n3 := p.calculateNormalAt(tuple.NewPoint(-5, 0, 150))
+	n1 := p.calculateNormalAt(tuple.NewPoint(0, 0, 0), nil)
+	n2 := p.calculateNormalAt(tuple.NewPoint(10, 0, -10), nil)
+	n3 := p.calculateNormalAt(tuple.NewPoint(-5, 0, 150), nil)
 
 	assert.True(t, n1.Equals(tuple.NewVector(0, 1, 0)))
 	assert.True(t, n2.Equals(tuple.NewVector(0, 1, 0)))
@@ -4,28 +4,41 @@ import (
 	"goray/material"
 	"goray/matrix"
 	"goray/ray"
+	"goray/transformation"
 	"goray/tuple"
 )
 
 type shapeType interface {
 	calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections
-	calculateNormalAt(point *tuple.Tuple) *tuple.Tuple
+	calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple
+	bounds() *Bounds
 }
 
 type Shape struct {
-	transformation *matrix.Matrix
-	material       *material.Material
-	shapeType      shapeType
+	transformation   *matrix.Matrix
+	inverse          *matrix.Matrix
+	inverseTranspose *matrix.Matrix
+	material         *material.Material
+	shapeType        shapeType
+	parent           *Shape
+	keyframes        []Keyframe
+	motion           []*transformation.Interpolator
 }
 
 func NewShape(shapeType shapeType) *Shape {
 	return &Shape{
-		transformation: matrix.NewIdentityMatrix4x4(),
-		material: material.NewMaterial(),
-		shapeType: shapeType,
+		transformation:   matrix.NewIdentityMatrix4x4(),
+		inverse:          matrix.NewIdentityMatrix4x4(),
+		inverseTranspose: matrix.NewIdentityMatrix4x4(),
+		material:         material.NewMaterial(),
+		shapeType:        shapeType,
 	}
 }
 
+func (s *Shape) GetParent() *Shape {
+	return s.parent
+}
+
 func (s *Shape) SetMaterial(m *material.Material) {
 	s.material = m
 }
@@ -34,26 +47,125 @@ func (s *Shape) GetMaterial() *material.Material {
 	return s.material
 }
 
+// SetTransformation places the shape statically, discarding any keyframes
 func (s *Shape) SetTransformation(m *matrix.Matrix) {
+	s.keyframes = nil
+	s.motion = nil
+	s.setTransformation(m)
+}
+
+func (s *Shape) setTransformation(m *matrix.Matrix) {
 	s.transformation = m
+	s.inverse = m.Invert()
+	s.inverseTranspose = s.inverse.Transpose()
+
+	if s.parent != nil {
+		s.parent.refreshBounds()
+	}
 }
 
 func (s *Shape) GetTransformation() *matrix.Matrix {
 	return s.transformation
 }
 
+func (s *Shape) GetInverse() *matrix.Matrix {
+	return s.inverse
+}
+
+func (s *Shape) GetInverseTranspose() *matrix.Matrix {
+	return s.inverseTranspose
+}
+
+func (s *Shape) Bounds() *Bounds {
+	return s.shapeType.bounds()
+}
+
+func (s *Shape) ParentSpaceBounds() *Bounds {
+	if s.IsMoving() {
+		return s.motionBounds()
+	}
+
+	return s.Bounds().Transform(s.transformation)
+}
+
+// refreshBounds recomputes cached bounds of composite shapes after one of
+// their descendants changed, walking up to the root
+func (s *Shape) refreshBounds() {
+	switch st := s.shapeType.(type) {
+	case *Group:
+		st.box = st.calculateBounds()
+	case *CSG:
+		st.box = st.calculateBounds()
+	}
+
+	if s.parent != nil {
+		s.parent.refreshBounds()
+	}
+}
+
+// growBounds merges the bounds of a newly added descendant into the cached
+// bounds of s and its ancestors; adding a shape can only grow them, so there
+// is no need to revisit the other children
+func (s *Shape) growBounds(added *Bounds) {
+	switch st := s.shapeType.(type) {
+	case *Group:
+		st.box.Merge(added)
+	case *CSG:
+		st.box.Merge(added)
+	}
+
+	if s.parent != nil {
+		s.parent.growBounds(s.ParentSpaceBounds())
+	}
+}
+
 func (s *Shape) Intersect(r *ray.Ray) ray.Intersections {
-	objectRay := r.Transform(s.transformation.Invert())
+	objectRay := r.Transform(s.inverseAt(r.Time))
 
-	return s.shapeType.calculateIntersections(objectRay, s)
+	xs := s.shapeType.calculateIntersections(objectRay, s)
+	for _, x := range xs.GetAll() {
+		x.Time = r.Time
+	}
+
+	return xs
 }
 
-func (s *Shape) NormalAt(point *tuple.Tuple) *tuple.Tuple {
-	objectPoint := s.transformation.Invert().MultiplyTuple(point)
-	objectNormal := s.shapeType.calculateNormalAt(objectPoint)
+func (s *Shape) WorldToObject(point *tuple.Tuple) *tuple.Tuple {
+	return s.WorldToObjectAt(point, 0)
+}
 
-	worldNormal := s.transformation.Invert().Transpose().MultiplyTuple(objectNormal)
+func (s *Shape) WorldToObjectAt(point *tuple.Tuple, time float64) *tuple.Tuple {
+	if s.parent != nil {
+		point = s.parent.WorldToObjectAt(point, time)
+	}
+
+	return s.inverseAt(time).MultiplyTuple(point)
+}
+
+func (s *Shape) NormalToWorld(normal *tuple.Tuple) *tuple.Tuple {
+	return s.NormalToWorldAt(normal, 0)
+}
+
+func (s *Shape) NormalToWorldAt(normal *tuple.Tuple, time float64) *tuple.Tuple {
+	worldNormal := s.inverseTransposeAt(time).MultiplyTuple(normal)
 	worldNormal.W = 0
+	worldNormal = worldNormal.Normalize()
+
+	if s.parent != nil {
+		return s.parent.NormalToWorldAt(worldNormal, time)
+	}
+
+	return worldNormal
+}
+
+func (s *Shape) NormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	time := 0.0
+	if hit != nil {
+		time = hit.Time
+	}
+
+	objectPoint := s.WorldToObjectAt(point, time)
+	objectNormal := s.shapeType.calculateNormalAt(objectPoint, hit)
 
-	return worldNormal.Normalize()
+	return s.NormalToWorldAt(objectNormal, time)
 }
@@ -22,14 +22,20 @@ func (ts TestShape) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersectio
 	return ray.Intersections{}
 }
 
-func (ts TestShape) calculateNormalAt(point *tuple.Tuple) *tuple.Tuple {
+func (ts TestShape) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
 	return tuple.NewVector(point.X, point.Y, point.Z)
 }
 
+func (ts TestShape) bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1))
+}
+
 func TestDefaultTransformation(t *testing.T) {
 	s := NewTestShape()
 
 	assert.True(t, s.transformation.Equals(matrix.NewIdentityMatrix4x4()))
+	assert.True(t, s.inverse.Equals(matrix.NewIdentityMatrix4x4()))
+	assert.True(t, s.inverseTranspose.Equals(matrix.NewIdentityMatrix4x4()))
 }
 
 func TestAssigningTransformation(t *testing.T) {
@@ -40,6 +46,16 @@ func TestAssigningTransformation(t *testing.T) {
 	assert.True(t, s.transformation.Equals(transformation.NewTranslation(2, 3, 4)))
 }
 
+func TestAssigningTransformationCachesInverse(t *testing.T) {
+	s := NewTestShape()
+	m := transformation.NewScaling(1, 0.5, 1).MultiplyMatrix(transformation.NewRotationZ(0.5))
+
+	s.SetTransformation(m)
+
+	assert.True(t, s.GetInverse().Equals(m.Invert()))
+	assert.True(t, s.GetInverseTranspose().Equals(m.Invert().Transpose()))
+}
+
 func TestDefaultMaterial(t *testing.T) {
 	s := NewTestShape()
 
@@ -56,3 +72,12 @@ func TestAssigningMaterial(t *testing.T) {
 
 	assert.Equal(t, m, s.material)
 }
+
+func TestConvertingPointFromWorldToObjectSpace(t *testing.T) {
+	s := NewTestShape()
+	s.SetTransformation(transformation.NewTranslation(5, 0, 0).MultiplyMatrix(transformation.NewScaling(2, 2, 2)))
+
+	p := s.WorldToObject(tuple.NewPoint(7, 2, -4))
+
+	assert.True(t, tuple.NewPoint(1, 1, -2).Equals(p))
+}
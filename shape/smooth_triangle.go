@@ -0,0 +1,25 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+)
+
+type SmoothTriangle struct {
+	Triangle
+	N1, N2, N3 *tuple.Tuple
+}
+
+func NewSmoothTriangle(p1, p2, p3, n1, n2, n3 *tuple.Tuple) *Shape {
+	return NewShape(&SmoothTriangle{Triangle: *newTriangle(p1, p2, p3), N1: n1, N2: n2, N3: n3})
+}
+
+func (st *SmoothTriangle) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	if hit == nil {
+		return st.Normal
+	}
+
+	return st.N2.Multiply(hit.U).
+		Add(st.N3.Multiply(hit.V)).
+		Add(st.N1.Multiply(1 - hit.U - hit.V))
+}
@@ -0,0 +1,62 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/tuple"
+	"testing"
+)
+
+func newTestSmoothTriangle() *Shape {
+	return NewSmoothTriangle(
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(-1, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		tuple.NewVector(0, 1, 0),
+		tuple.NewVector(-1, 0, 0),
+		tuple.NewVector(1, 0, 0),
+	)
+}
+
+func TestConstructingSmoothTriangle(t *testing.T) {
+	s := newTestSmoothTriangle()
+	tr := s.shapeType.(*SmoothTriangle)
+
+	assert.Equal(t, tuple.NewPoint(0, 1, 0), tr.P1)
+	assert.Equal(t, tuple.NewPoint(-1, 0, 0), tr.P2)
+	assert.Equal(t, tuple.NewPoint(1, 0, 0), tr.P3)
+	assert.Equal(t, tuple.NewVector(0, 1, 0), tr.N1)
+	assert.Equal(t, tuple.NewVector(-1, 0, 0), tr.N2)
+	assert.Equal(t, tuple.NewVector(1, 0, 0), tr.N3)
+}
+
+func TestIntersectionWithSmoothTriangleStoresUV(t *testing.T) {
+	s := newTestSmoothTriangle()
+	r := ray.NewRay(tuple.NewPoint(-0.2, 0.3, -2), tuple.NewVector(0, 0, 1))
+
+	xs := s.Intersect(r)
+
+	require.Equal(t, 1, xs.Len())
+	assert.InDelta(t, 0.45, xs.Get(0).U, 0.00001)
+	assert.InDelta(t, 0.25, xs.Get(0).V, 0.00001)
+}
+
+func TestSmoothTriangleUsesUVToInterpolateNormal(t *testing.T) {
+	s := newTestSmoothTriangle()
+	i := ray.NewIntersectionWithUV(1, s, 0.45, 0.25)
+
+	n := s.NormalAt(tuple.NewPoint(0, 0, 0), i)
+
+	assert.True(t, tuple.NewVector(-0.5547, 0.83205, 0).Equals(n))
+}
+
+func TestPreparingNormalOnSmoothTriangle(t *testing.T) {
+	s := newTestSmoothTriangle()
+	i := ray.NewIntersectionWithUV(1, s, 0.45, 0.25)
+	r := ray.NewRay(tuple.NewPoint(-0.2, 0.3, -2), tuple.NewVector(0, 0, 1))
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+
+	assert.True(t, tuple.NewVector(-0.5547, 0.83205, 0).Equals(comps.NormalV))
+}
@@ -35,6 +35,18 @@ func (sp Sphere) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections
 	return *xs
 }
 
-func (sp Sphere) calculateNormalAt(point *tuple.Tuple) *tuple.Tuple {
+func (sp Sphere) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
 	return point.Sub(tuple.NewPoint(0, 0, 0))
 }
+
+func NewGlassSphere() *Shape {
+	s := NewSphere()
+	s.GetMaterial().Transparency = 1.0
+	s.GetMaterial().RefractiveIndex = 1.5
+
+	return s
+}
+
+func (sp Sphere) bounds() *Bounds {
+	return NewBounds(tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1))
+}
@@ -94,7 +94,7 @@ func TestChangingSphereTransformation(t *testing.T) {
 func TestIntersectingScaledSphere(t *testing.T) {
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
 	s := NewSphere()
-	s.transformation = transformation.NewScaling(2, 2, 2)
+	s.SetTransformation(transformation.NewScaling(2, 2, 2))
 
 	xs := s.Intersect(r)
 
@@ -107,7 +107,7 @@ func TestIntersectingScaledSphere(t *testing.T) {
 func TestIntersectingTranslatedSphere(t *testing.T) {
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
 	s := NewSphere()
-	s.transformation = transformation.NewTranslation(5, 0, 0)
+	s.SetTransformation(transformation.NewTranslation(5, 0, 0))
 
 	xs := s.Intersect(r)
 
@@ -117,7 +117,7 @@ func TestIntersectingTranslatedSphere(t *testing.T) {
 func TestNormalAtPointOnXAxis(t *testing.T) {
 	s := NewSphere()
 
-	n := s.NormalAt(tuple.NewPoint(1, 0, 0))
+	n := s.NormalAt(tuple.NewPoint(1, 0, 0), nil)
 
 	assert.Equal(t, tuple.NewVector(1, 0, 0), n)
 }
@@ -125,7 +125,7 @@ func TestNormalAtPointOnXAxis(t *testing.T) {
 func TestNormalAtPointOnYAxis(t *testing.T) {
 	s := NewSphere()
 
-	n := s.NormalAt(tuple.NewPoint(0, 1, 0))
+	n := s.NormalAt(tuple.NewPoint(0, 1, 0), nil)
 
 	assert.Equal(t, tuple.NewVector(0, 1, 0), n)
 }
@@ -133,7 +133,7 @@ func TestNormalAtPointOnYAxis(t *testing.T) {
 func TestNormalAtPointOnZAxis(t *testing.T) {
 	s := NewSphere()
 
-	n := s.NormalAt(tuple.NewPoint(0, 0, 1))
+	n := s.NormalAt(tuple.NewPoint(0, 0, 1), nil)
 
 	assert.Equal(t, tuple.NewVector(0, 0, 1), n)
 }
@@ -141,7 +141,7 @@ func TestNormalAtPointOnZAxis(t *testing.T) {
 func TestNormalAtPointOnNonAxialPoint(t *testing.T) {
 	s := NewSphere()
 
-	n := s.NormalAt(tuple.NewPoint(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3))
+	n := s.NormalAt(tuple.NewPoint(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3), nil)
 
 	assert.Equal(t, tuple.NewVector(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3), n)
 }
@@ -149,25 +149,25 @@ func TestNormalAtPointOnNonAxialPoint(t *testing.T) {
 func TestNormalIsNormalized(t *testing.T) {
 	s := NewSphere()
 
-	n := s.NormalAt(tuple.NewPoint(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3))
+	n := s.NormalAt(tuple.NewPoint(math.Sqrt(3)/3, math.Sqrt(3)/3, math.Sqrt(3)/3), nil)
 
 	assert.Equal(t, n.Normalize(), n)
 }
 
 func TestNormalOnTranslatedSphere(t *testing.T) {
 	s := NewSphere()
-	s.transformation = transformation.NewTranslation(0, 1, 0)
+	s.SetTransformation(transformation.NewTranslation(0, 1, 0))
 
-	n := s.NormalAt(tuple.NewPoint(0, 1.70711, -0.70711))
+	n := s.NormalAt(tuple.NewPoint(0, 1.70711, -0.70711), nil)
 
 	assert.True(t, tuple.NewVector(0, 0.70711, -0.70711).Equals(n))
 }
 
 func TestNormalOnTransformedSphere(t *testing.T) {
 	s := NewSphere()
-	s.transformation = transformation.NewScaling(1, 0.5, 1).MultiplyMatrix(transformation.NewRotationZ(math.Pi / 5))
+	s.SetTransformation(transformation.NewScaling(1, 0.5, 1).MultiplyMatrix(transformation.NewRotationZ(math.Pi / 5)))
 
-	n := s.NormalAt(tuple.NewPoint(0, math.Sqrt(2)/2, -math.Sqrt(2)/2))
+	n := s.NormalAt(tuple.NewPoint(0, math.Sqrt(2)/2, -math.Sqrt(2)/2), nil)
 
 	assert.True(t, tuple.NewVector(0, 0.97014, -0.24254).Equals(n))
 }
@@ -187,3 +187,11 @@ func TestChangingSphereMaterial(t *testing.T) {
 
 	assert.Equal(t, m, s.material)
 }
+
+func TestGlassSphere(t *testing.T) {
+	s := NewGlassSphere()
+
+	assert.True(t, matrix.NewIdentityMatrix4x4().Equals(s.GetTransformation()))
+	assert.Equal(t, 1.0, s.GetMaterial().Transparency)
+	assert.Equal(t, 1.5, s.GetMaterial().RefractiveIndex)
+}
@@ -0,0 +1,79 @@
+package shape
+
+import (
+	"goray/ray"
+	"goray/tuple"
+	"goray/utils"
+	"math"
+)
+
+type Triangle struct {
+	P1, P2, P3 *tuple.Tuple
+	E1, E2     *tuple.Tuple
+	Normal     *tuple.Tuple
+}
+
+func NewTriangle(p1, p2, p3 *tuple.Tuple) *Shape {
+	return NewShape(newTriangle(p1, p2, p3))
+}
+
+func newTriangle(p1, p2, p3 *tuple.Tuple) *Triangle {
+	e1 := p2.Sub(p1)
+	e2 := p3.Sub(p1)
+
+	return &Triangle{
+		P1:     p1,
+		P2:     p2,
+		P3:     p3,
+		E1:     e1,
+		E2:     e2,
+		Normal: e2.Cross(e1).Normalize(),
+	}
+}
+
+func (tr *Triangle) calculateIntersections(r *ray.Ray, s *Shape) ray.Intersections {
+	t, u, v, ok := tr.intersect(r)
+	if !ok {
+		return ray.Intersections{}
+	}
+
+	return *ray.NewIntersections(ray.NewIntersectionWithUV(t, s, u, v))
+}
+
+// intersect implements the Möller–Trumbore algorithm and returns the distance
+// along the ray together with the barycentric u and v of the hit
+func (tr *Triangle) intersect(r *ray.Ray) (float64, float64, float64, bool) {
+	dirCrossE2 := r.Direction.Cross(tr.E2)
+	det := tr.E1.Dot(dirCrossE2)
+	if math.Abs(det) < utils.EPSILON {
+		return 0, 0, 0, false
+	}
+
+	f := 1.0 / det
+	p1ToOrigin := r.Origin.Sub(tr.P1)
+	u := f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(tr.E1)
+	v := f * r.Direction.Dot(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+
+	return f * tr.E2.Dot(originCrossE1), u, v, true
+}
+
+func (tr *Triangle) calculateNormalAt(point *tuple.Tuple, hit *ray.Intersection) *tuple.Tuple {
+	return tr.Normal
+}
+
+func (tr *Triangle) bounds() *Bounds {
+	b := NewEmptyBounds()
+	b.AddPoint(tr.P1)
+	b.AddPoint(tr.P2)
+	b.AddPoint(tr.P3)
+
+	return b
+}
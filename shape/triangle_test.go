@@ -0,0 +1,76 @@
+package shape
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"goray/ray"
+	"goray/tuple"
+	"testing"
+)
+
+func newTestTriangle() *Triangle {
+	return newTriangle(tuple.NewPoint(0, 1, 0), tuple.NewPoint(-1, 0, 0), tuple.NewPoint(1, 0, 0))
+}
+
+func TestConstructingTriangle(t *testing.T) {
+	p1 := tuple.NewPoint(0, 1, 0)
+	p2 := tuple.NewPoint(-1, 0, 0)
+	p3 := tuple.NewPoint(1, 0, 0)
+
+	tr := newTriangle(p1, p2, p3)
+
+	assert.Equal(t, p1, tr.P1)
+	assert.Equal(t, p2, tr.P2)
+	assert.Equal(t, p3, tr.P3)
+	assert.True(t, tuple.NewVector(-1, -1, 0).Equals(tr.E1))
+	assert.True(t, tuple.NewVector(1, -1, 0).Equals(tr.E2))
+	assert.True(t, tuple.NewVector(0, 0, -1).Equals(tr.Normal))
+}
+
+func TestNormalOnTriangle(t *testing.T) {
+	tr := newTestTriangle()
+
+	n1 := tr.calculateNormalAt(tuple.NewPoint(0, 0.5, 0), nil)
+	n2 := tr.calculateNormalAt(tuple.NewPoint(-0.5, 0.75, 0), nil)
+	n3 := tr.calculateNormalAt(tuple.NewPoint(0.5, 0.25, 0), nil)
+
+	assert.Equal(t, tr.Normal, n1)
+	assert.Equal(t, tr.Normal, n2)
+	assert.Equal(t, tr.Normal, n3)
+}
+
+func TestIntersectingRayParallelToTriangle(t *testing.T) {
+	tr := newTestTriangle()
+	r := ray.NewRay(tuple.NewPoint(0, -1, -2), tuple.NewVector(0, 1, 0))
+
+	xs := tr.calculateIntersections(r, &Shape{})
+
+	assert.Zero(t, xs.Len())
+}
+
+func TestRayMissesTriangleEdges(t *testing.T) {
+	tr := newTestTriangle()
+	origins := []*tuple.Tuple{
+		tuple.NewPoint(1, 1, -2),
+		tuple.NewPoint(-1, 1, -2),
+		tuple.NewPoint(0, -1, -2),
+	}
+
+	for _, origin := range origins {
+		r := ray.NewRay(origin, tuple.NewVector(0, 0, 1))
+
+		xs := tr.calculateIntersections(r, &Shape{})
+
+		assert.Zero(t, xs.Len())
+	}
+}
+
+func TestRayStrikesTriangle(t *testing.T) {
+	tr := newTestTriangle()
+	r := ray.NewRay(tuple.NewPoint(0, 0.5, -2), tuple.NewVector(0, 0, 1))
+
+	xs := tr.calculateIntersections(r, &Shape{})
+
+	require.Equal(t, 1, xs.Len())
+	assert.InDelta(t, 2.0, xs.ValueAt(0), 0.00001)
+}
@@ -0,0 +1,327 @@
+package transformation
+
+import (
+	"goray/matrix"
+	"goray/tuple"
+	"math"
+)
+
+type mat3 [3][3]float64
+
+// decomposition splits an affine transformation into translation * rotation
+// * scale, where scale may also carry shear
+type decomposition struct {
+	translation [3]float64
+	rotation    [4]float64 // quaternion w, x, y, z
+	scale       mat3
+}
+
+// Interpolate blends two affine transformations; t = 0 yields a and t = 1
+// yields b. Translation and scale are interpolated linearly and rotation
+// along the shortest arc, so a spinning object keeps its shape instead of
+// collapsing as it would with element-wise blending.
+func Interpolate(a, b *matrix.Matrix, t float64) *matrix.Matrix {
+	if t <= 0 {
+		return a
+	}
+	if t >= 1 {
+		return b
+	}
+
+	return newInterpolator(a, b).At(t)
+}
+
+// Interpolator blends between the same two transformations as Interpolate,
+// but decomposes them once up front so that each step only has to blend
+type Interpolator struct {
+	a, b               *matrix.Matrix
+	aInverse, bInverse *matrix.Matrix
+	da, db             decomposition
+	// theta is the angle between the two rotations, zero when they are too
+	// close for slerp to be stable
+	theta, sinTheta float64
+	// angle is how far the rotation turns from a to b
+	angle float64
+}
+
+func NewInterpolator(a, b *matrix.Matrix) *Interpolator {
+	in := newInterpolator(a, b)
+	in.aInverse = a.Invert()
+	in.bInverse = b.Invert()
+
+	return in
+}
+
+func newInterpolator(a, b *matrix.Matrix) *Interpolator {
+	in := &Interpolator{a: a, b: b, da: decompose(a), db: decompose(b)}
+
+	qa, qb := in.da.rotation, &in.db.rotation
+	dot := qa[0]*qb[0] + qa[1]*qb[1] + qa[2]*qb[2] + qa[3]*qb[3]
+	if dot < 0 {
+		dot = -dot
+		*qb = [4]float64{-qb[0], -qb[1], -qb[2], -qb[3]}
+	}
+	in.angle = 2 * math.Acos(math.Min(dot, 1))
+	if dot < 0.9995 {
+		in.theta = math.Acos(dot)
+		in.sinTheta = math.Sin(in.theta)
+	}
+
+	return in
+}
+
+// At returns the transformation a fraction t of the way from a to b
+func (in *Interpolator) At(t float64) *matrix.Matrix {
+	if t <= 0 {
+		return in.a
+	}
+	if t >= 1 {
+		return in.b
+	}
+
+	rs, translation := in.blend(t)
+
+	return matrix.NewMatrix(4, 4,
+		rs[0][0], rs[0][1], rs[0][2], translation[0],
+		rs[1][0], rs[1][1], rs[1][2], translation[1],
+		rs[2][0], rs[2][1], rs[2][2], translation[2],
+		0, 0, 0, 1,
+	)
+}
+
+// InverseAt returns the inverse of At(t), built from the blended rotation and
+// scale rather than by inverting the whole matrix
+func (in *Interpolator) InverseAt(t float64) *matrix.Matrix {
+	if t <= 0 {
+		return in.aInverse
+	}
+	if t >= 1 {
+		return in.bInverse
+	}
+
+	rs, translation := in.blend(t)
+	inv := rs.inverse()
+
+	var offset [3]float64
+	for i := range offset {
+		for j := 0; j < 3; j++ {
+			offset[i] -= inv[i][j] * translation[j]
+		}
+	}
+
+	return matrix.NewMatrix(4, 4,
+		inv[0][0], inv[0][1], inv[0][2], offset[0],
+		inv[1][0], inv[1][1], inv[1][2], offset[1],
+		inv[2][0], inv[2][1], inv[2][2], offset[2],
+		0, 0, 0, 1,
+	)
+}
+
+// MaxChordDeviation bounds how far any point of the box from min to max
+// strays from the straight line between its positions at neighbouring steps
+// when the interpolation is sampled at steps even intervals. Only rotation
+// bends a point's path, so without it the bound is zero
+func (in *Interpolator) MaxChordDeviation(min, max *tuple.Tuple, steps int) float64 {
+	if in.angle == 0 {
+		return 0
+	}
+
+	// for a point x the path is translation(t) + rotation(t) * y(t), with
+	// y(t) = scale(t) * x linear in t. Its second derivative is bounded by
+	// w^2 |y| + 2 w |y'| for the angular speed w, and a curve whose second
+	// derivative stays below k is within k h^2 / 8 of its chords of length h
+	maxY, maxDY := 0.0, 0.0
+	for _, x := range [2]float64{min.X, max.X} {
+		for _, y := range [2]float64{min.Y, max.Y} {
+			for _, z := range [2]float64{min.Z, max.Z} {
+				corner := [3]float64{x, y, z}
+				ya, yb := in.da.scale.apply(corner), in.db.scale.apply(corner)
+
+				maxY = math.Max(maxY, math.Max(length(ya), length(yb)))
+				maxDY = math.Max(maxDY, length([3]float64{yb[0] - ya[0], yb[1] - ya[1], yb[2] - ya[2]}))
+			}
+		}
+	}
+
+	h := 1 / float64(steps)
+	k := in.angle*in.angle*maxY + 2*in.angle*maxDY
+
+	return k * h * h / 8
+}
+
+// blend returns the rotation * scale part and the translation at t
+func (in *Interpolator) blend(t float64) (mat3, [3]float64) {
+	da, db := in.da, in.db
+
+	var translation [3]float64
+	for i := range translation {
+		translation[i] = lerp(da.translation[i], db.translation[i], t)
+	}
+
+	var scale mat3
+	for i := range scale {
+		for j := range scale[i] {
+			scale[i][j] = lerp(da.scale[i][j], db.scale[i][j], t)
+		}
+	}
+
+	wa, wb := 1-t, t
+	if in.theta != 0 {
+		wa = math.Sin((1-t)*in.theta) / in.sinTheta
+		wb = math.Sin(t*in.theta) / in.sinTheta
+	}
+
+	var q [4]float64
+	length := 0.0
+	for i := range q {
+		q[i] = wa*da.rotation[i] + wb*db.rotation[i]
+		length += q[i] * q[i]
+	}
+	length = math.Sqrt(length)
+	for i := range q {
+		q[i] /= length
+	}
+
+	return quaternionToMatrix(q).multiply(scale), translation
+}
+
+func decompose(m *matrix.Matrix) decomposition {
+	var d decomposition
+	var a mat3
+	for i := 0; i < 3; i++ {
+		d.translation[i] = m.At(i, 3)
+		for j := 0; j < 3; j++ {
+			a[i][j] = m.At(i, j)
+		}
+	}
+
+	// polar decomposition: averaging a matrix with its inverse transpose
+	// converges on the closest rotation
+	r := a
+	for iteration := 0; iteration < 100; iteration++ {
+		inverseTranspose := r.inverse().transpose()
+
+		var next mat3
+		diff := 0.0
+		for i := range next {
+			for j := range next[i] {
+				next[i][j] = 0.5 * (r[i][j] + inverseTranspose[i][j])
+				diff = math.Max(diff, math.Abs(next[i][j]-r[i][j]))
+			}
+		}
+		r = next
+
+		if diff < 1e-12 {
+			break
+		}
+	}
+
+	// a mirroring transformation leaves a reflection in r; move it into the
+	// scale so r stays a proper rotation
+	if r.determinant() < 0 {
+		for i := range r {
+			for j := range r[i] {
+				r[i][j] = -r[i][j]
+			}
+		}
+	}
+
+	d.rotation = matrixToQuaternion(r)
+	d.scale = r.transpose().multiply(a)
+
+	return d
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+func matrixToQuaternion(r mat3) [4]float64 {
+	trace := r[0][0] + r[1][1] + r[2][2]
+
+	switch {
+	case trace > 0:
+		s := 0.5 / math.Sqrt(trace+1)
+		return [4]float64{0.25 / s, (r[2][1] - r[1][2]) * s, (r[0][2] - r[2][0]) * s, (r[1][0] - r[0][1]) * s}
+	case r[0][0] > r[1][1] && r[0][0] > r[2][2]:
+		s := 2 * math.Sqrt(1+r[0][0]-r[1][1]-r[2][2])
+		return [4]float64{(r[2][1] - r[1][2]) / s, 0.25 * s, (r[0][1] + r[1][0]) / s, (r[0][2] + r[2][0]) / s}
+	case r[1][1] > r[2][2]:
+		s := 2 * math.Sqrt(1+r[1][1]-r[0][0]-r[2][2])
+		return [4]float64{(r[0][2] - r[2][0]) / s, (r[0][1] + r[1][0]) / s, 0.25 * s, (r[1][2] + r[2][1]) / s}
+	}
+
+	s := 2 * math.Sqrt(1+r[2][2]-r[0][0]-r[1][1])
+	return [4]float64{(r[1][0] - r[0][1]) / s, (r[0][2] + r[2][0]) / s, (r[1][2] + r[2][1]) / s, 0.25 * s}
+}
+
+func quaternionToMatrix(q [4]float64) mat3 {
+	w, x, y, z := q[0], q[1], q[2], q[3]
+
+	return mat3{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}
+}
+
+func (m mat3) multiply(other mat3) mat3 {
+	var product mat3
+	for i := range product {
+		for j := range product[i] {
+			for k := 0; k < 3; k++ {
+				product[i][j] += m[i][k] * other[k][j]
+			}
+		}
+	}
+
+	return product
+}
+
+func (m mat3) apply(v [3]float64) [3]float64 {
+	var product [3]float64
+	for i := range product {
+		for k := 0; k < 3; k++ {
+			product[i] += m[i][k] * v[k]
+		}
+	}
+
+	return product
+}
+
+func length(v [3]float64) float64 {
+	return math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+}
+
+func (m mat3) transpose() mat3 {
+	var t mat3
+	for i := range t {
+		for j := range t[i] {
+			t[i][j] = m[j][i]
+		}
+	}
+
+	return t
+}
+
+func (m mat3) determinant() float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+func (m mat3) inverse() mat3 {
+	det := m.determinant()
+
+	var inv mat3
+	for i := range inv {
+		for j := range inv[i] {
+			// cofactor of m[j][i], which transposes the cofactor matrix
+			r0, r1 := (j+1)%3, (j+2)%3
+			c0, c1 := (i+1)%3, (i+2)%3
+			inv[i][j] = (m[r0][c0]*m[r1][c1] - m[r0][c1]*m[r1][c0]) / det
+		}
+	}
+
+	return inv
+}
@@ -0,0 +1,79 @@
+package transformation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"goray/tuple"
+	"math"
+	"testing"
+)
+
+func TestInterpolatingReturnsEndpoints(t *testing.T) {
+	a := NewTranslation(1, 2, 3)
+	b := NewRotationY(math.Pi / 2).MultiplyMatrix(NewScaling(2, 2, 2))
+
+	assert.True(t, a.Equals(Interpolate(a, b, 0)))
+	assert.True(t, b.Equals(Interpolate(a, b, 1)))
+}
+
+func TestInterpolatingTranslation(t *testing.T) {
+	m := Interpolate(NewTranslation(0, 0, 0), NewTranslation(4, -2, 10), 0.25)
+
+	assert.True(t, NewTranslation(1, -0.5, 2.5).Equals(m))
+}
+
+func TestInterpolatingScaling(t *testing.T) {
+	m := Interpolate(NewScaling(1, 1, 1), NewScaling(3, 5, 1), 0.5)
+
+	assert.True(t, NewScaling(2, 3, 1).Equals(m))
+}
+
+func TestInterpolatingRotationFollowsArc(t *testing.T) {
+	m := Interpolate(NewRotationZ(0), NewRotationZ(math.Pi/2), 0.5)
+
+	assert.True(t, NewRotationZ(math.Pi/4).Equals(m))
+	// element-wise blending would shrink the point towards the origin
+	p := m.MultiplyTuple(tuple.NewPoint(1, 0, 0))
+	assert.InDelta(t, 1, math.Hypot(p.X, p.Y), 0.00001)
+}
+
+func TestInterpolatingCombinedTransformation(t *testing.T) {
+	a := NewTranslation(0, 1, 0).MultiplyMatrix(NewRotationX(0)).MultiplyMatrix(NewScaling(1, 2, 1))
+	b := NewTranslation(2, 1, 0).MultiplyMatrix(NewRotationX(math.Pi / 3)).MultiplyMatrix(NewScaling(3, 2, 1))
+
+	m := Interpolate(a, b, 0.5)
+
+	expected := NewTranslation(1, 1, 0).MultiplyMatrix(NewRotationX(math.Pi / 6)).MultiplyMatrix(NewScaling(2, 2, 1))
+	assert.True(t, expected.Equals(m))
+}
+
+func TestInterpolatingMirroredTransformation(t *testing.T) {
+	a := NewScaling(-1, 1, 1)
+	b := NewScaling(-3, 1, 1)
+
+	assert.True(t, NewScaling(-2, 1, 1).Equals(Interpolate(a, b, 0.5)))
+}
+
+func TestInterpolatorInverseMatchesInvertedStep(t *testing.T) {
+	a := NewTranslation(0, 1, 0).MultiplyMatrix(NewRotationX(0)).MultiplyMatrix(NewScaling(1, 2, 1))
+	b := NewTranslation(2, 1, 0).MultiplyMatrix(NewRotationX(math.Pi / 3)).MultiplyMatrix(NewScaling(3, 2, 1))
+	in := NewInterpolator(a, b)
+
+	for _, step := range []float64{-1, 0, 0.3, 0.5, 1, 2} {
+		m := in.At(step)
+
+		assert.True(t, Interpolate(a, b, step).Equals(m), "t = %v", step)
+		assert.True(t, m.Invert().Equals(in.InverseAt(step)), "t = %v", step)
+	}
+}
+
+func TestChordDeviationOnlyComesFromRotation(t *testing.T) {
+	min, max := tuple.NewPoint(-1, -1, -1), tuple.NewPoint(1, 1, 1)
+
+	moving := NewInterpolator(NewTranslation(0, 0, 0), NewTranslation(5, 0, 0).MultiplyMatrix(NewScaling(2, 2, 2)))
+	assert.Equal(t, 0.0, moving.MaxChordDeviation(min, max, 4))
+
+	// a corner sqrt(3) from the axis turning a quarter circle in 4 steps
+	// strays sqrt(3) * (1 - cos(pi/16)) from its chords; the bound must cover it
+	spinning := NewInterpolator(NewRotationY(0), NewRotationY(math.Pi/2))
+	assert.GreaterOrEqual(t, spinning.MaxChordDeviation(min, max, 4), math.Sqrt(3)*(1-math.Cos(math.Pi/16)))
+}
@@ -8,16 +8,26 @@ import (
 	"goray/shape"
 	"goray/transformation"
 	"goray/tuple"
+	"math"
 	"sort"
 )
 
+const DefaultMaxDepth = 5
+
 type World struct {
-	Light   *light.Light
-	Objects []ray.Object
+	Lights   []*light.Light
+	Objects  []ray.Object
+	MaxDepth int
+
+	TransparentShadows bool
 }
 
 func NewWorld() *World {
-	return &World{}
+	return &World{MaxDepth: DefaultMaxDepth}
+}
+
+func (w *World) AddLight(l *light.Light) {
+	w.Lights = append(w.Lights, l)
 }
 
 func NewDefaultWorld() *World {
@@ -32,11 +42,35 @@ func NewDefaultWorld() *World {
 	s2.SetTransformation(transformation.NewScaling(0.5, 0.5, 0.5))
 
 	return &World{
-		Light:   light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1)),
-		Objects: []ray.Object{s1, s2},
+		Lights:   []*light.Light{light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1))},
+		Objects:  []ray.Object{s1, s2},
+		MaxDepth: DefaultMaxDepth,
 	}
 }
 
+// Divide replaces the shapes in Objects with a single bounding volume
+// hierarchy so rays can skip whole subtrees; call it once the scene is built
+func (w *World) Divide(threshold int) {
+	var shapes []*shape.Shape
+	var others []ray.Object
+	for _, obj := range w.Objects {
+		if s, ok := obj.(*shape.Shape); ok {
+			shapes = append(shapes, s)
+		} else {
+			others = append(others, obj)
+		}
+	}
+
+	if len(shapes) == 0 {
+		return
+	}
+
+	root := shape.NewGroup(shapes...)
+	root.Divide(threshold)
+
+	w.Objects = append(others, root)
+}
+
 func (w *World) Intersect(r *ray.Ray) *ray.Intersections {
 	var worldXs []*ray.Intersection
 	for _, obj := range w.Objects {
@@ -52,31 +86,145 @@ func (w *World) Intersect(r *ray.Ray) *ray.Intersections {
 	return xs
 }
 
-func (w *World) ShadeHit(comps *ray.Computation) *color.Color {
-	return comps.Object.GetMaterial().Lighting(w.Light, comps.OverPoint, comps.EyeV, comps.NormalV, w.IsShadowed(comps.OverPoint))
+func (w *World) ShadeHit(comps *ray.Computation, remaining int) *color.Color {
+	object := objectAtTime{comps.Object, comps.Time}
+
+	surface := color.NewColor(0, 0, 0)
+	for _, l := range w.Lights {
+		samples := l.SamplesFrom(comps.OverPoint, comps.Jitter)
+		intensity := w.intensityAt(samples, comps.OverPoint, comps.Time)
+		surface = surface.Add(comps.Object.GetMaterial().LightingSamples(object, l, samples, comps.OverPoint, comps.EyeV, comps.NormalV, intensity))
+	}
+
+	reflected := w.ReflectedColor(comps, remaining)
+	refracted := w.RefractedColor(comps, remaining)
+
+	m := comps.Object.GetMaterial()
+	if m.Reflective > 0 && m.Transparency > 0 {
+		reflectance := comps.Schlick()
+		return surface.Add(reflected.MultiplyScalar(reflectance)).Add(refracted.MultiplyScalar(1 - reflectance))
+	}
+
+	return surface.Add(reflected).Add(refracted)
 }
 
-func (w *World) ColorAt(r *ray.Ray) *color.Color {
+func (w *World) ColorAt(r *ray.Ray, remaining int) *color.Color {
 	xs := w.Intersect(r)
 
 	if xs.Hit() == nil {
 		return color.NewColor(0, 0, 0)
 	}
 
-	comps := xs.Hit().PrepareComputations(r)
+	comps := xs.Hit().PrepareComputations(r, xs)
+
+	return w.ShadeHit(comps, remaining)
+}
+
+func (w *World) ReflectedColor(comps *ray.Computation, remaining int) *color.Color {
+	reflective := comps.Object.GetMaterial().Reflective
+	if remaining <= 0 || reflective == 0 {
+		return color.NewColor(0, 0, 0)
+	}
+
+	reflectRay := ray.NewRay(comps.OverPoint, comps.ReflectV)
+	reflectRay.Time = comps.Time
+	reflectRay.Jitter = comps.Jitter
+	c := w.ColorAt(reflectRay, remaining-1)
+
+	return c.MultiplyScalar(reflective)
+}
+
+func (w *World) RefractedColor(comps *ray.Computation, remaining int) *color.Color {
+	transparency := comps.Object.GetMaterial().Transparency
+	if remaining <= 0 || transparency == 0 {
+		return color.NewColor(0, 0, 0)
+	}
+
+	nRatio := comps.N1 / comps.N2
+	cosI := comps.EyeV.Dot(comps.NormalV)
+	sin2T := nRatio * nRatio * (1 - cosI*cosI)
+	if sin2T > 1 {
+		return color.NewColor(0, 0, 0)
+	}
+
+	cosT := math.Sqrt(1.0 - sin2T)
+	direction := comps.NormalV.Multiply(nRatio*cosI - cosT).Sub(comps.EyeV.Multiply(nRatio))
+
+	refractRay := ray.NewRay(comps.UnderPoint, direction)
+	refractRay.Time = comps.Time
+	refractRay.Jitter = comps.Jitter
+	c := w.ColorAt(refractRay, remaining-1)
 
-	return w.ShadeHit(comps)
+	return c.MultiplyScalar(transparency)
 }
 
+// IsShadowed reports whether p is hidden from every light in the world. A
+// world without lights casts no shadows, so nothing in it is shadowed
 func (w *World) IsShadowed(p *tuple.Tuple) bool {
-	v := w.Light.Position.Sub(p)
+	if len(w.Lights) == 0 {
+		return false
+	}
+
+	for _, l := range w.Lights {
+		if w.IntensityAt(l, p) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IntensityAt returns the fraction of l's samples that are visible from p,
+// sampling area lights at the centre of each cell
+func (w *World) IntensityAt(l *light.Light, p *tuple.Tuple) float64 {
+	return w.intensityAt(l.SamplesFrom(p, nil), p, 0)
+}
+
+func (w *World) intensityAt(samples []light.Sample, p *tuple.Tuple, time float64) float64 {
+	total := 0.0
+	for _, sample := range samples {
+		r := ray.NewRay(p, sample.Direction)
+		r.Time = time
+		if !w.isShadowed(r, sample.Distance) {
+			total++
+		}
+	}
+
+	return total / float64(len(samples))
+}
+
+func (w *World) isShadowedFrom(lightPosition, p *tuple.Tuple) bool {
+	v := lightPosition.Sub(p)
 	distance := v.Magnitude()
-	direction := v.Normalize()
 
-	r := ray.NewRay(p, direction)
+	return w.isShadowed(ray.NewRay(p, v.Divide(distance)), distance)
+}
+
+// isShadowed reports whether anything opaque lies along r closer than
+// distance
+func (w *World) isShadowed(r *ray.Ray, distance float64) bool {
 	xs := w.Intersect(r)
 
-	hit := xs.Hit()
+	for _, x := range xs.GetAll() {
+		if x.T <= 0 || x.T >= distance {
+			continue
+		}
+		if w.TransparentShadows && x.Object.GetMaterial().Transparency > 0 {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
+// objectAtTime lets patterns on moving objects follow them by resolving
+// points in the object's pose at the time of the hit
+type objectAtTime struct {
+	object ray.Object
+	time   float64
+}
 
-	return hit != nil && hit.T < distance
+func (o objectAtTime) WorldToObject(point *tuple.Tuple) *tuple.Tuple {
+	return o.object.WorldToObjectAt(point, o.time)
 }
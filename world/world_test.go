@@ -2,20 +2,25 @@ package world
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"goray/color"
 	"goray/light"
+	"goray/material"
 	"goray/ray"
 	"goray/shape"
 	"goray/transformation"
 	"goray/tuple"
+	"math"
+	"sync"
 	"testing"
 )
 
 func TestEmptyWorld(t *testing.T) {
 	w := NewWorld()
 
-	assert.Nil(t, w.Light)
+	assert.Empty(t, w.Lights)
 	assert.Len(t, w.Objects, 0)
+	assert.Equal(t, DefaultMaxDepth, w.MaxDepth)
 }
 
 func TestDefaultWorld(t *testing.T) {
@@ -31,7 +36,7 @@ func TestDefaultWorld(t *testing.T) {
 	s2 := shape.NewSphere()
 	s2.SetTransformation(transformation.NewScaling(0.5, 0.5, 0.5))
 
-	assert.Equal(t, l, w.Light)
+	assert.Equal(t, []*light.Light{l}, w.Lights)
 	assert.Contains(t, w.Objects, s1)
 	assert.Contains(t, w.Objects, s2)
 }
@@ -55,21 +60,21 @@ func TestShadingIntersection(t *testing.T) {
 	s := w.Objects[0]
 	i := ray.NewIntersection(4, s)
 
-	comps := i.PrepareComputations(r)
-	c := w.ShadeHit(comps)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ShadeHit(comps, DefaultMaxDepth)
 
 	assert.True(t, color.NewColor(0.38066, 0.47583, 0.2855).Equals(c))
 }
 
 func TestShadingIntersectionFromTheInside(t *testing.T) {
 	w := NewDefaultWorld()
-	w.Light = light.NewPointLight(tuple.NewPoint(0, 0.25, 0), color.NewColor(1, 1, 1))
+	w.Lights = []*light.Light{light.NewPointLight(tuple.NewPoint(0, 0.25, 0), color.NewColor(1, 1, 1))}
 	r := ray.NewRay(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
 	s := w.Objects[1]
 	i := ray.NewIntersection(0.5, s)
 
-	comps := i.PrepareComputations(r)
-	c := w.ShadeHit(comps)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ShadeHit(comps, DefaultMaxDepth)
 
 	assert.True(t, color.NewColor(0.90498, 0.90498, 0.90498).Equals(c))
 }
@@ -78,7 +83,7 @@ func TestColorWhenRayMisses(t *testing.T) {
 	w := NewDefaultWorld()
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 1, 0))
 
-	c := w.ColorAt(r)
+	c := w.ColorAt(r, DefaultMaxDepth)
 
 	assert.True(t, color.NewColor(0, 0, 0).Equals(c))
 }
@@ -87,7 +92,7 @@ func TestColorWhenRayHits(t *testing.T) {
 	w := NewDefaultWorld()
 	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
 
-	c := w.ColorAt(r)
+	c := w.ColorAt(r, DefaultMaxDepth)
 
 	assert.True(t, color.NewColor(0.38066, 0.47583, 0.2855).Equals(c))
 }
@@ -100,7 +105,7 @@ func TestColorWithIntersectionBehindRay(t *testing.T) {
 	inner.GetMaterial().Ambient = 1
 	r := ray.NewRay(tuple.NewPoint(0, 0, 0.75), tuple.NewVector(0, 0, -1))
 
-	c := w.ColorAt(r)
+	c := w.ColorAt(r, DefaultMaxDepth)
 
 	assert.True(t, inner.GetMaterial().Color.Equals(c))
 }
@@ -126,6 +131,14 @@ func TestNoShadowWhenObjectIsBehindLight(t *testing.T) {
 	assert.False(t, w.IsShadowed(p))
 }
 
+func TestNoShadowInWorldWithoutLights(t *testing.T) {
+	w := NewDefaultWorld()
+	w.Lights = nil
+	p := tuple.NewPoint(10, -10, 10)
+
+	assert.False(t, w.IsShadowed(p))
+}
+
 func TestNoShadowWhenObjectIsBehindPoint(t *testing.T) {
 	w := NewDefaultWorld()
 	p := tuple.NewPoint(-2, 2, -2)
@@ -135,7 +148,7 @@ func TestNoShadowWhenObjectIsBehindPoint(t *testing.T) {
 
 func TestShadingIntersectionInShadow(t *testing.T) {
 	w := NewWorld()
-	w.Light = light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))
+	w.Lights = []*light.Light{light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1))}
 	s1 := shape.NewSphere()
 	s2 := shape.NewSphere()
 	s2.SetTransformation(transformation.NewTranslation(0, 0, 10))
@@ -143,8 +156,397 @@ func TestShadingIntersectionInShadow(t *testing.T) {
 	r := ray.NewRay(tuple.NewPoint(0, 0, 5), tuple.NewVector(0, 0, 1))
 	i := ray.NewIntersection(4, s2)
 
-	comps := i.PrepareComputations(r)
-	c := w.ShadeHit(comps)
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ShadeHit(comps, DefaultMaxDepth)
 
 	assert.True(t, c.Equals(color.NewColor(0.1, 0.1, 0.1)))
 }
+
+func TestConcurrentReadsOfWorld(t *testing.T) {
+	w := NewDefaultWorld()
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	expected := w.ColorAt(r, DefaultMaxDepth)
+
+	var wg sync.WaitGroup
+	results := make([]*color.Color, 16)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = w.ColorAt(r, DefaultMaxDepth)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, c := range results {
+		assert.Equal(t, expected, c)
+	}
+}
+
+func TestReflectedColorForNonreflectiveMaterial(t *testing.T) {
+	w := NewDefaultWorld()
+	r := ray.NewRay(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+	s := w.Objects[1]
+	s.GetMaterial().Ambient = 1
+	i := ray.NewIntersection(1, s)
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ReflectedColor(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0, 0, 0).Equals(c))
+}
+
+func TestReflectedColorForReflectiveMaterial(t *testing.T) {
+	w := NewDefaultWorld()
+	s := shape.NewPlane()
+	s.GetMaterial().Reflective = 0.5
+	s.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	w.Objects = append(w.Objects, s)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := ray.NewIntersection(math.Sqrt(2), s)
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ReflectedColor(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0.19033, 0.23791, 0.14274).Equals(c))
+}
+
+func TestShadeHitWithReflectiveMaterial(t *testing.T) {
+	w := NewDefaultWorld()
+	s := shape.NewPlane()
+	s.GetMaterial().Reflective = 0.5
+	s.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	w.Objects = append(w.Objects, s)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := ray.NewIntersection(math.Sqrt(2), s)
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ShadeHit(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0.87676, 0.92434, 0.82917).Equals(c))
+}
+
+func TestColorAtWithMutuallyReflectiveSurfaces(t *testing.T) {
+	w := NewWorld()
+	w.Lights = []*light.Light{light.NewPointLight(tuple.NewPoint(0, 0, 0), color.NewColor(1, 1, 1))}
+	m := material.NewMaterial()
+	m.Reflective = 1
+	lower := shape.NewPlane()
+	lower.SetMaterial(m)
+	lower.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	upper := shape.NewPlane()
+	upper.SetMaterial(m)
+	upper.SetTransformation(transformation.NewTranslation(0, 1, 0))
+	w.Objects = []ray.Object{lower, upper}
+	r := ray.NewRay(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0))
+
+	assert.NotPanics(t, func() {
+		w.ColorAt(r, w.MaxDepth)
+	})
+}
+
+func TestReflectedColorAtMaximumRecursiveDepth(t *testing.T) {
+	w := NewDefaultWorld()
+	s := shape.NewPlane()
+	s.GetMaterial().Reflective = 0.5
+	s.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	w.Objects = append(w.Objects, s)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	i := ray.NewIntersection(math.Sqrt(2), s)
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ReflectedColor(comps, 0)
+
+	assert.True(t, color.NewColor(0, 0, 0).Equals(c))
+}
+
+func TestRefractedColorWithOpaqueSurface(t *testing.T) {
+	w := NewDefaultWorld()
+	s := w.Objects[0]
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	xs := ray.NewIntersections(ray.NewIntersection(4, s), ray.NewIntersection(6, s))
+
+	comps := xs.Get(0).PrepareComputations(r, xs)
+	c := w.RefractedColor(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0, 0, 0).Equals(c))
+}
+
+func TestRefractedColorAtMaximumRecursiveDepth(t *testing.T) {
+	w := NewDefaultWorld()
+	s := w.Objects[0]
+	s.GetMaterial().Transparency = 1.0
+	s.GetMaterial().RefractiveIndex = 1.5
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	xs := ray.NewIntersections(ray.NewIntersection(4, s), ray.NewIntersection(6, s))
+
+	comps := xs.Get(0).PrepareComputations(r, xs)
+	c := w.RefractedColor(comps, 0)
+
+	assert.True(t, color.NewColor(0, 0, 0).Equals(c))
+}
+
+func TestRefractedColorUnderTotalInternalReflection(t *testing.T) {
+	w := NewDefaultWorld()
+	s := w.Objects[0]
+	s.GetMaterial().Transparency = 1.0
+	s.GetMaterial().RefractiveIndex = 1.5
+	r := ray.NewRay(tuple.NewPoint(0, 0, math.Sqrt(2)/2), tuple.NewVector(0, 1, 0))
+	xs := ray.NewIntersections(ray.NewIntersection(-math.Sqrt(2)/2, s), ray.NewIntersection(math.Sqrt(2)/2, s))
+
+	comps := xs.Get(1).PrepareComputations(r, xs)
+	c := w.RefractedColor(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0, 0, 0).Equals(c))
+}
+
+func TestShadeHitWithTransparentMaterial(t *testing.T) {
+	w := NewDefaultWorld()
+	floor := shape.NewPlane()
+	floor.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	floor.GetMaterial().Transparency = 0.5
+	floor.GetMaterial().RefractiveIndex = 1.5
+	ball := shape.NewSphere()
+	ball.GetMaterial().Color = color.NewColor(1, 0, 0)
+	ball.GetMaterial().Ambient = 0.5
+	ball.SetTransformation(transformation.NewTranslation(0, -3.5, -0.5))
+	w.Objects = append(w.Objects, floor, ball)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	xs := ray.NewIntersections(ray.NewIntersection(math.Sqrt(2), floor))
+
+	comps := xs.Get(0).PrepareComputations(r, xs)
+	c := w.ShadeHit(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0.93642, 0.68642, 0.68642).Equals(c))
+}
+
+func TestShadeHitWithReflectiveTransparentMaterial(t *testing.T) {
+	w := NewDefaultWorld()
+	floor := shape.NewPlane()
+	floor.SetTransformation(transformation.NewTranslation(0, -1, 0))
+	floor.GetMaterial().Reflective = 0.5
+	floor.GetMaterial().Transparency = 0.5
+	floor.GetMaterial().RefractiveIndex = 1.5
+	ball := shape.NewSphere()
+	ball.GetMaterial().Color = color.NewColor(1, 0, 0)
+	ball.GetMaterial().Ambient = 0.5
+	ball.SetTransformation(transformation.NewTranslation(0, -3.5, -0.5))
+	w.Objects = append(w.Objects, floor, ball)
+	r := ray.NewRay(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	xs := ray.NewIntersections(ray.NewIntersection(math.Sqrt(2), floor))
+
+	comps := xs.Get(0).PrepareComputations(r, xs)
+	c := w.ShadeHit(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(0.93391, 0.69643, 0.69243).Equals(c))
+}
+
+func TestTransparentObjectsCastShadowsByDefault(t *testing.T) {
+	w := NewDefaultWorld()
+	for _, o := range w.Objects {
+		o.GetMaterial().Transparency = 1.0
+	}
+	p := tuple.NewPoint(10, -10, 10)
+
+	assert.True(t, w.IsShadowed(p))
+}
+
+func TestTransparentShadowsLetLightThroughTransparentObjects(t *testing.T) {
+	w := NewDefaultWorld()
+	w.TransparentShadows = true
+	p := tuple.NewPoint(10, -10, 10)
+
+	assert.True(t, w.IsShadowed(p))
+
+	for _, o := range w.Objects {
+		o.GetMaterial().Transparency = 1.0
+	}
+
+	assert.False(t, w.IsShadowed(p))
+}
+
+func TestDividingWorldWrapsShapesInHierarchy(t *testing.T) {
+	w := NewDefaultWorld()
+	for i := 0; i < 10; i++ {
+		s := shape.NewSphere()
+		s.SetTransformation(transformation.NewTranslation(float64(i*3), 0, 10))
+		w.Objects = append(w.Objects, s)
+	}
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	before := w.Intersect(r)
+
+	w.Divide(4)
+	after := w.Intersect(r)
+
+	assert.Len(t, w.Objects, 1)
+	assert.Equal(t, before.Len(), after.Len())
+	for i := 0; i < before.Len(); i++ {
+		assert.Equal(t, before.ValueAt(i), after.ValueAt(i))
+		assert.Equal(t, before.ObjectAt(i), after.ObjectAt(i))
+	}
+}
+
+func TestIsShadowedTestsOcclusionBetweenTwoPoints(t *testing.T) {
+	w := NewDefaultWorld()
+	lightPosition := tuple.NewPoint(-10, -10, -10)
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected bool
+	}{
+		{tuple.NewPoint(-10, -10, 10), false},
+		{tuple.NewPoint(10, 10, 10), true},
+		{tuple.NewPoint(-20, -20, -20), false},
+		{tuple.NewPoint(-5, -5, -5), false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, w.isShadowedFrom(lightPosition, tc.point), "%v", tc.point)
+	}
+}
+
+func TestPointLightsEvaluateLightIntensityAtPoint(t *testing.T) {
+	w := NewDefaultWorld()
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected float64
+	}{
+		{tuple.NewPoint(0, 1.0001, 0), 1.0},
+		{tuple.NewPoint(-1.0001, 0, 0), 1.0},
+		{tuple.NewPoint(0, 0, -1.0001), 1.0},
+		{tuple.NewPoint(0, 0, 1.0001), 0.0},
+		{tuple.NewPoint(1.0001, 0, 0), 0.0},
+		{tuple.NewPoint(0, -1.0001, 0), 0.0},
+		{tuple.NewPoint(0, 0, 0), 0.0},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, w.IntensityAt(w.Lights[0], tc.point), "%v", tc.point)
+	}
+}
+
+func TestAreaLightsEvaluateIntensityAtPoint(t *testing.T) {
+	w := NewDefaultWorld()
+	l := light.NewJitteredAreaLight(tuple.NewPoint(-0.5, -0.5, -5), tuple.NewVector(1, 0, 0), 2, tuple.NewVector(0, 1, 0), 2, color.NewColor(1, 1, 1))
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected float64
+	}{
+		{tuple.NewPoint(0, 0, 2), 0.0},
+		{tuple.NewPoint(1, -1, 2), 0.25},
+		{tuple.NewPoint(1.5, 0, 2), 0.5},
+		{tuple.NewPoint(1.25, 1.25, 3), 0.75},
+		{tuple.NewPoint(0, 0, -2), 1.0},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, w.IntensityAt(l, tc.point), "%v", tc.point)
+	}
+}
+
+func TestJitteredAreaLightsEvaluateIntensityAtPoint(t *testing.T) {
+	w := NewDefaultWorld()
+	l := light.NewJitteredAreaLight(tuple.NewPoint(-0.5, -0.5, -5), tuple.NewVector(1, 0, 0), 2, tuple.NewVector(0, 1, 0), 2, color.NewColor(1, 1, 1))
+
+	cases := []struct {
+		point    *tuple.Tuple
+		expected float64
+	}{
+		{tuple.NewPoint(0, 0, 2), 0.0},
+		{tuple.NewPoint(1, -1, 2), 0.5},
+		{tuple.NewPoint(1.5, 0, 2), 0.75},
+		{tuple.NewPoint(1.25, 1.25, 3), 0.75},
+		{tuple.NewPoint(0, 0, -2), 1.0},
+	}
+
+	for _, tc := range cases {
+		samples := l.SamplesFrom(tc.point, light.NewSequence(0.7, 0.3, 0.9, 0.1, 0.5))
+
+		assert.Equal(t, tc.expected, w.intensityAt(samples, tc.point, 0), "%v", tc.point)
+	}
+}
+
+func TestShadeHitSumsContributionOfEveryLight(t *testing.T) {
+	w := NewDefaultWorld()
+	r := ray.NewRay(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	i := ray.NewIntersection(4, w.Objects[0])
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	single := w.ShadeHit(comps, DefaultMaxDepth)
+
+	w.AddLight(light.NewPointLight(tuple.NewPoint(-10, 10, -10), color.NewColor(1, 1, 1)))
+	doubled := w.ShadeHit(comps, DefaultMaxDepth)
+
+	assert.True(t, single.MultiplyScalar(2).Equals(doubled))
+}
+
+func TestShadeHitTestsShadowsPerLight(t *testing.T) {
+	w := NewWorld()
+	w.AddLight(light.NewPointLight(tuple.NewPoint(0, 0, -10), color.NewColor(1, 1, 1)))
+	w.AddLight(light.NewPointLight(tuple.NewPoint(0, 0, 20), color.NewColor(1, 1, 1)))
+	s1 := shape.NewSphere()
+	s2 := shape.NewSphere()
+	s2.SetTransformation(transformation.NewTranslation(0, 0, 10))
+	w.Objects = []ray.Object{s1, s2}
+	r := ray.NewRay(tuple.NewPoint(0, 0, 5), tuple.NewVector(0, 0, 1))
+	i := ray.NewIntersection(4, s2)
+
+	comps := i.PrepareComputations(r, ray.NewIntersections(i))
+	c := w.ShadeHit(comps, DefaultMaxDepth)
+
+	// the first light is blocked by s1 and the second is behind s2's surface,
+	// so only the ambient term of each light remains
+	assert.True(t, color.NewColor(0.2, 0.2, 0.2).Equals(c))
+	assert.True(t, w.IsShadowed(comps.OverPoint))
+}
+
+func TestPointIsNotShadowedWhileAnyLightReachesIt(t *testing.T) {
+	w := NewDefaultWorld()
+	p := tuple.NewPoint(10, -10, 10)
+	require.True(t, w.IsShadowed(p))
+
+	w.AddLight(light.NewPointLight(tuple.NewPoint(10, -10, 20), color.NewColor(1, 1, 1)))
+
+	assert.False(t, w.IsShadowed(p))
+}
+
+func TestDirectionalLightCastsShadowsFromAnyDistance(t *testing.T) {
+	w := NewDefaultWorld()
+	w.Lights = []*light.Light{light.NewDirectionalLight(tuple.NewVector(0, -1, 0), color.NewColor(1, 1, 1))}
+
+	assert.Equal(t, 0.0, w.IntensityAt(w.Lights[0], tuple.NewPoint(0, -1000, 0)))
+	assert.Equal(t, 1.0, w.IntensityAt(w.Lights[0], tuple.NewPoint(0, 1.0001, 0)))
+	assert.Equal(t, 1.0, w.IntensityAt(w.Lights[0], tuple.NewPoint(5, -1000, 0)))
+}
+
+func TestShadowsOfMovingObjectsDependOnTime(t *testing.T) {
+	w := NewWorld()
+	w.AddLight(light.NewPointLight(tuple.NewPoint(0, 10, 0), color.NewColor(1, 1, 1)))
+	blocker := shape.NewSphere()
+	blocker.SetMotion(transformation.NewTranslation(5, 5, 0), transformation.NewTranslation(0, 5, 0))
+	floor := shape.NewPlane()
+	w.Objects = []ray.Object{blocker, floor}
+	r := ray.NewRay(tuple.NewPoint(0, 1, -1), tuple.NewVector(0, -1, 1).Normalize())
+	xs := w.Intersect(r)
+	comps := xs.Hit().PrepareComputations(r, xs)
+
+	lit := w.ShadeHit(comps, DefaultMaxDepth)
+
+	r.Time = 1
+	xs = w.Intersect(r)
+	comps = xs.Hit().PrepareComputations(r, xs)
+	shadowed := w.ShadeHit(comps, DefaultMaxDepth)
+
+	assert.True(t, color.NewColor(1, 1, 1).Equals(lit))
+	assert.True(t, color.NewColor(0.1, 0.1, 0.1).Equals(shadowed))
+}
+
+func TestPatternsFollowMovingObjects(t *testing.T) {
+	s := shape.NewSphere()
+	s.SetMotion(transformation.NewTranslation(0, 0, 0), transformation.NewTranslation(2, 0, 0))
+
+	p := objectAtTime{s, 0.5}.WorldToObject(tuple.NewPoint(1.5, 0, 0))
+
+	assert.True(t, tuple.NewPoint(0.5, 0, 0).Equals(p))
+}